@@ -1,5 +1,10 @@
 package dogma
 
+import (
+	"context"
+	"time"
+)
+
 // A AggregateMessageHandler models business logic and state.
 //
 // Aggregates are the primary building blocks of an application's domain logic.
@@ -49,10 +54,53 @@ type AggregateMessageHandler interface {
 	// return value of New(). Otherwise, it's the value of the root as it
 	// existed after handling the command.
 	//
+	// The handler MUST NOT perform I/O. The context allows the handler to honor
+	// the engine's deadlines, such as by abandoning a CPU-intensive computation,
+	// and carries request-scoped values such as tracing information. The
+	// handler SHOULD return promptly once the context is canceled. If the
+	// context is canceled before the method returns, the engine MUST discard
+	// any events recorded and any call to Destroy() made within the scope. As
+	// RecordEvent() has already applied those events to the root, the engine
+	// MUST also discard the root, and load the instance afresh before handling
+	// any subsequent command.
+	//
+	// The handler SHOULD return a non-nil error only to indicate a transient
+	// or infrastructure failure, such as a failure to unmarshal some part of
+	// the root. Use AggregateCommandScope.Reject() to refuse the command for a
+	// business reason. If the handler returns an error the engine MUST discard
+	// any events recorded, any call to Destroy() made within the scope and the
+	// root itself, and SHOULD retry the command.
+	//
 	// While the engine MAY call this method concurrently from separate
 	// goroutines or operating system processes, the state changes and events
 	// that represent them always appear to have occurred sequentially.
-	HandleCommand(AggregateRoot, AggregateCommandScope, Command)
+	HandleCommand(context.Context, AggregateRoot, AggregateCommandScope, Command) error
+}
+
+// MultiInstanceCommandRouter is an optional interface that an
+// [AggregateMessageHandler] MAY implement to route a single command to more
+// than one instance.
+//
+// It's intended for commands that legitimately affect a bounded set of
+// instances, such as applying a fee to each account in a batch, without the
+// need for an intermediate process.
+type MultiInstanceCommandRouter interface {
+	// RouteCommandToInstances returns the IDs of the instances that handle a
+	// specific command.
+	//
+	// The return value MUST NOT be empty, and MUST NOT contain empty or
+	// duplicate IDs.
+	//
+	// If the handler implements this interface, the engine MUST call this
+	// method instead of RouteCommandToInstance(). The engine calls
+	// HandleCommand() once for each instance. Each call is independent; the
+	// engine does not guarantee that all instances handle the command
+	// atomically, nor the order in which they do so.
+	//
+	// If this method returns more than one ID for a command that was executed
+	// with the [WithResult] option, the engine MUST NOT handle the command, and
+	// the call to ExecuteCommand() MUST return a non-nil error.
+	RouteCommandToInstances(Command) []string
 }
 
 // AggregateRoot is an interface for the domain-specific state of a specific
@@ -71,6 +119,37 @@ type AggregateRoot interface {
 	ApplyEvent(Event)
 }
 
+// SnapshotBehavior is an optional interface that an [AggregateRoot] MAY
+// implement to allow the engine to persist snapshots of an aggregate
+// instance's state.
+//
+// It's intended for long-lived instances with a large number of historical
+// events, such that the engine can load the instance from its most recent
+// snapshot instead of applying every historical event.
+//
+// Snapshots are an optimization only. The engine MAY take a snapshot after
+// handling any command, and MAY discard snapshots at any time, such as when
+// the application is upgraded or when a snapshot fails to unmarshal. The
+// instance's events remain the authoritative record of its state; a snapshot
+// MUST represent the same state as applying all of the events recorded prior
+// to the snapshot being taken.
+type SnapshotBehavior interface {
+	// MarshalSnapshot returns a binary representation of the root's state.
+	MarshalSnapshot() ([]byte, error)
+
+	// UnmarshalSnapshot replaces the root's state with the state encoded in
+	// data, which is the result of a prior call to MarshalSnapshot().
+	//
+	// The engine calls this method on a root returned by the handler's New()
+	// method, then applies any events recorded after the snapshot was taken.
+	//
+	// It MUST return an error if data can not be unmarshaled, such as when
+	// the snapshot was produced by an incompatible version of the
+	// application. The engine SHOULD then discard the snapshot and load the
+	// instance by applying its entire history.
+	UnmarshalSnapshot(data []byte) error
+}
+
 // An AggregateConfigurer configures the engine for use with a specific
 // aggregate message handler.
 type AggregateConfigurer interface {
@@ -95,6 +174,12 @@ type AggregateConfigurer interface {
 	// types.
 	Routes(...AggregateRoute)
 
+	// LifetimePolicy configures how long the engine keeps the handler's
+	// instances in primary storage.
+	//
+	// The default policy is IndefiniteAggregateLifetimePolicy.
+	LifetimePolicy(AggregateLifetimePolicy)
+
 	// Disable prevents the handler from receiving any messages.
 	//
 	// The engine MUST NOT call any methods other than Configure() on a disabled
@@ -105,14 +190,36 @@ type AggregateConfigurer interface {
 	// without requiring the user to conditionally register the handler with the
 	// application.
 	Disable(...DisableOption)
+
+	// Warn records a non-fatal warning about the handler's configuration.
+	//
+	// The engine SHOULD report warnings prominently when it starts, such that
+	// the developer is able to address them. For example, an application may
+	// use warnings to announce that a route is deprecated.
+	Warn(format string, args ...any)
 }
 
 // AggregateCommandScope performs engine operations within the context of a call
 // to the HandleCommand() method of an [AggregateMessageHandler].
 type AggregateCommandScope interface {
+	HandlerScope
+
 	// InstanceID returns the ID of the aggregate instance.
 	InstanceID() string
 
+	// Revision returns the total number of events that were recorded against
+	// the instance before the current command, such that it's zero for the
+	// first command routed to the instance.
+	//
+	// The count includes events recorded before the instance was destroyed, and
+	// events that the engine has since archived or deleted. Therefore, the
+	// revision never decreases, and the engine MUST persist it even if it
+	// discards the events themselves. This allows the revision to be used as
+	// a concurrency token, such as in optimistic-concurrency responses to
+	// external callers. It does not change when RecordEvent() is called on
+	// this scope.
+	Revision() uint64
+
 	// RecordEvent records the occurrence of an event.
 	//
 	// It applies the event to the root such that the applied changes are
@@ -121,6 +228,32 @@ type AggregateCommandScope interface {
 	// Recording an event cancels any prior call to Destroy() on this scope.
 	RecordEvent(Event)
 
+	// Reject signals that the aggregate refuses to handle the command for a
+	// business reason, such as the command violating one of the aggregate's
+	// invariants.
+	//
+	// reason describes why the command was rejected. It MUST NOT be nil.
+	//
+	// Rejection is distinct from failure; it's a legitimate outcome of
+	// handling the command. The engine MUST NOT retry a rejected command, and
+	// SHOULD report rejections separately from failures.
+	//
+	// Rejection takes priority over every other call made within this scope.
+	// Once HandleCommand() returns, the engine MUST discard any events
+	// recorded and any call to Destroy() made within this scope, whether they
+	// occurred before or after the call to Reject(). It MUST also discard the
+	// root, as RecordEvent() has already applied any recorded events to it. If
+	// Reject() is called more than once, the reason from the first call is
+	// used. If HandleCommand() returns a non-nil error, the attempt has failed
+	// and the rejection is discarded along with the rest of the attempt's
+	// outcome.
+	//
+	// If the command was executed with the [WithResult] option, the caller's
+	// call to ExecuteCommand() returns a [CommandRejectedError] that wraps
+	// reason. Otherwise, the reason is not returned to the caller, and the
+	// engine SHOULD record it as a diagnostic.
+	Reject(reason error)
+
 	// Destroy signals destruction of the aggregate instance.
 	//
 	// Destroying an aggregate instance discards its state. The first command to
@@ -134,8 +267,20 @@ type AggregateCommandScope interface {
 	// aggregate's historical events.
 	Destroy()
 
-	// Log records an informational message.
-	Log(format string, args ...any)
+	// SetResult sets a value that's returned to the caller that executed the
+	// command, if the caller passed the [WithResult] option to
+	// ExecuteCommand().
+	//
+	// It's intended for small values that the caller needs immediately, such
+	// as the ID of a newly created entity. The engine MAY transmit the value
+	// between operating system processes, so it SHOULD be a value of a simple
+	// type, such as a string.
+	//
+	// Each call replaces the value set by any prior call on this scope. If the
+	// caller did not request a result, or the attempt to handle the command
+	// fails, the engine discards the value. Use Reject() to refuse the
+	// command for a business reason.
+	SetResult(v any)
 }
 
 // AggregateRoute describes a message type that's routed to or from a
@@ -144,3 +289,36 @@ type AggregateRoute interface {
 	Route
 	isAggregateRoute()
 }
+
+type (
+	// An AggregateLifetimePolicy describes how long the engine keeps an
+	// [AggregateMessageHandler]'s instances in primary storage.
+	//
+	// Under all policies, an instance's state MUST be preserved. The engine
+	// MUST restore an offloaded instance before routing a command to it.
+	AggregateLifetimePolicy interface{ isAggregateLifetimePolicy() }
+
+	// IndefiniteAggregateLifetimePolicy is the default
+	// [AggregateLifetimePolicy]. It keeps instances in primary storage until
+	// they're destroyed.
+	IndefiniteAggregateLifetimePolicy struct{}
+
+	// ArchiveAggregateLifetimePolicy is an [AggregateLifetimePolicy] that
+	// allows the engine to move instances to archival storage once they've
+	// been inactive for a certain period. Restoring an archived instance MAY
+	// be significantly slower than loading an instance from primary storage.
+	//
+	// It's the instance-level counterpart of [ArchiveEventRetentionPolicy],
+	// which archives individual events based on their age. The two policies
+	// are independent: archiving an instance does not archive the events it
+	// recorded, unless their routes use an [ArchiveEventRetentionPolicy], and
+	// archiving events does not archive the instance. Under either policy the
+	// engine MUST be able to load the instance's complete state before routing
+	// a command to it.
+	ArchiveAggregateLifetimePolicy struct {
+		// After is the minimum period of time since the instance last handled
+		// a command before it may be archived. It MUST be positive; the engine
+		// MUST report a configuration error if it's not.
+		After time.Duration
+	}
+)
@@ -2,3 +2,6 @@ package dogma
 
 func (HandlesCommandRoute) isAggregateRoute() {}
 func (RecordsEventRoute) isAggregateRoute()   {}
+
+func (IndefiniteAggregateLifetimePolicy) isAggregateLifetimePolicy() {}
+func (ArchiveAggregateLifetimePolicy) isAggregateLifetimePolicy()    {}
@@ -0,0 +1,77 @@
+package dogma
+
+import (
+	"context"
+	"fmt"
+)
+
+// AggregateCommandCase is a strongly-typed handler for a single command type,
+// for use with [DispatchAggregateCommand].
+//
+// R is the concrete type of the aggregate's root.
+type AggregateCommandCase[R AggregateRoot] struct {
+	handle func(context.Context, R, AggregateCommandScope, Command) (bool, error)
+}
+
+// HandleAggregateCommand returns an [AggregateCommandCase] that calls fn when
+// the command is of type C.
+func HandleAggregateCommand[R AggregateRoot, C Command](
+	fn func(context.Context, R, AggregateCommandScope, C) error,
+) AggregateCommandCase[R] {
+	if fn == nil {
+		panic("command handler function must not be nil")
+	}
+
+	return AggregateCommandCase[R]{
+		func(ctx context.Context, r R, s AggregateCommandScope, c Command) (bool, error) {
+			if c, ok := c.(C); ok {
+				return true, fn(ctx, r, s, c)
+			}
+			return false, nil
+		},
+	}
+}
+
+// DispatchAggregateCommand calls the first of the given cases that matches the
+// type of c.
+//
+// It's intended for use within the HandleCommand() method of an
+// [AggregateMessageHandler], such that the handler's business logic can be
+// written as strongly-typed functions without a type switch. For example:
+//
+//	func (h *CartHandler) HandleCommand(
+//		ctx context.Context,
+//		r dogma.AggregateRoot,
+//		s dogma.AggregateCommandScope,
+//		c dogma.Command,
+//	) error {
+//		return dogma.DispatchAggregateCommand(
+//			ctx, r, s, c,
+//			dogma.HandleAggregateCommand(h.addItem),
+//			dogma.HandleAggregateCommand(h.removeItem),
+//		)
+//	}
+//
+// It panics if r is not of type R. It calls [PanicUnexpectedMessage] if none of
+// the cases match the type of c.
+func DispatchAggregateCommand[R AggregateRoot](
+	ctx context.Context,
+	r AggregateRoot,
+	s AggregateCommandScope,
+	c Command,
+	cases ...AggregateCommandCase[R],
+) error {
+	root, ok := r.(R)
+	if !ok {
+		panic(fmt.Sprintf("unexpected aggregate root type: %T", r))
+	}
+
+	for _, x := range cases {
+		if ok, err := x.handle(ctx, root, s, c); ok {
+			return err
+		}
+	}
+
+	PanicUnexpectedMessage(c)
+	return nil // unreachable
+}
@@ -0,0 +1,111 @@
+package dogma_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/dogmatiq/dogma"
+)
+
+type (
+	dispatchRoot struct {
+		AggregateRoot
+		handled []Command
+	}
+
+	dispatchCommandA struct {
+		NoPayloadCommand[dispatchCommandA]
+	}
+
+	dispatchCommandB struct {
+		NoPayloadCommand[dispatchCommandB]
+	}
+)
+
+func TestDispatchAggregateCommand(t *testing.T) {
+	handleA := HandleAggregateCommand(
+		func(_ context.Context, r *dispatchRoot, _ AggregateCommandScope, c dispatchCommandA) error {
+			r.handled = append(r.handled, c)
+			return nil
+		},
+	)
+
+	errB := errors.New("<error>")
+	handleB := HandleAggregateCommand(
+		func(_ context.Context, r *dispatchRoot, _ AggregateCommandScope, c dispatchCommandB) error {
+			r.handled = append(r.handled, c)
+			return errB
+		},
+	)
+
+	t.Run("it calls the case that matches the command type", func(t *testing.T) {
+		r := &dispatchRoot{}
+
+		err := DispatchAggregateCommand(
+			context.Background(),
+			r,
+			nil,
+			dispatchCommandB{},
+			handleA,
+			handleB,
+		)
+
+		if err != errB {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(r.handled) != 1 || r.handled[0] != (dispatchCommandB{}) {
+			t.Fatalf("unexpected handled commands: %v", r.handled)
+		}
+	})
+
+	t.Run("it panics if no case matches the command type", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			if !ok || !errors.Is(err, UnexpectedMessage) {
+				t.Fatalf("unexpected panic value: %v", r)
+			}
+		}()
+
+		DispatchAggregateCommand(
+			context.Background(),
+			&dispatchRoot{},
+			nil,
+			noPayloadCommand{},
+			handleA,
+			handleB,
+		)
+	})
+
+	t.Run("it panics if the root is not of the expected type", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+
+		type otherRoot struct{ AggregateRoot }
+
+		DispatchAggregateCommand(
+			context.Background(),
+			otherRoot{},
+			nil,
+			dispatchCommandA{},
+			handleA,
+		)
+	})
+}
+
+func TestHandleAggregateCommand(t *testing.T) {
+	t.Run("it panics if the function is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+
+		HandleAggregateCommand[*dispatchRoot, dispatchCommandA](nil)
+	})
+}
@@ -27,6 +27,28 @@ type ApplicationConfigurer interface {
 	// handlers.
 	Routes(...HandlerRoute)
 
+	// MessageIDStrategy configures how the engine generates IDs for the
+	// messages produced by the application.
+	//
+	// If no strategy is configured, the engine MAY use any strategy.
+	MessageIDStrategy(MessageIDStrategy)
+
+	// Requires declares that the application requires the engine to provide
+	// specific features or resources.
+	//
+	// The engine MUST refuse to start the application if it can't satisfy
+	// every requirement, and SHOULD report which requirements are unmet. This
+	// allows misconfiguration to be detected at startup, rather than as
+	// incorrect behavior at runtime.
+	Requires(...EngineRequirement)
+
+	// Warn records a non-fatal warning about the application's configuration.
+	//
+	// The engine SHOULD report warnings prominently when it starts, such that
+	// the developer is able to address them. For example, an application may
+	// use warnings to announce that a route is deprecated.
+	Warn(format string, args ...any)
+
 	// RegisterAggregate configures the engine to route messages for an
 	// aggregate.
 	//
@@ -80,3 +102,51 @@ type (
 	// Deprecated: Use [ViaProjectionOption] instead.
 	RegisterProjectionOption struct{}
 )
+
+type (
+	// A MessageIDStrategy describes how the engine generates message IDs.
+	//
+	// The choice of strategy can affect the performance of the storage
+	// systems used by the engine, as it determines the locality of IDs that
+	// are generated at similar times.
+	MessageIDStrategy interface{ isMessageIDStrategy() }
+
+	// RandomMessageIDStrategy is a [MessageIDStrategy] that generates random
+	// message IDs, such as RFC 9562 version 4 UUIDs.
+	RandomMessageIDStrategy struct{}
+
+	// TimeOrderedMessageIDStrategy is a [MessageIDStrategy] that generates
+	// message IDs that sort in the order in which they were generated, such as
+	// RFC 9562 version 7 UUIDs.
+	TimeOrderedMessageIDStrategy struct{}
+)
+
+type (
+	// An EngineRequirement describes a feature or resource that an application
+	// requires the engine to provide. See [ApplicationConfigurer].Requires().
+	EngineRequirement interface{ isEngineRequirement() }
+
+	// TimeoutSchedulingRequirement is an [EngineRequirement] that requires the
+	// engine to deliver [Timeout] messages scheduled by processes.
+	TimeoutSchedulingRequirement struct{}
+
+	// SnapshotStorageRequirement is an [EngineRequirement] that requires the
+	// engine to provide persistent storage for snapshots of aggregate roots
+	// that implement [SnapshotBehavior].
+	SnapshotStorageRequirement struct{}
+
+	// CheckpointGranularityRequirement is an [EngineRequirement] that requires
+	// the engine to advance each projection's checkpoint after handling no
+	// more than MaxEvents events.
+	//
+	// It applies to every projection in the application, except those that
+	// explicitly permit larger batches using the [WithCheckpointBatching]
+	// option; the option takes precedence for that projection only.
+	CheckpointGranularityRequirement struct {
+		// MaxEvents is the maximum number of events that the engine may
+		// handle before advancing a projection's checkpoint. It MUST be
+		// positive; the engine MUST report a configuration error if it's
+		// not.
+		MaxEvents int
+	}
+)
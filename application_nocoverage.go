@@ -0,0 +1,8 @@
+package dogma
+
+func (RandomMessageIDStrategy) isMessageIDStrategy()      {}
+func (TimeOrderedMessageIDStrategy) isMessageIDStrategy() {}
+
+func (TimeoutSchedulingRequirement) isEngineRequirement()     {}
+func (SnapshotStorageRequirement) isEngineRequirement()       {}
+func (CheckpointGranularityRequirement) isEngineRequirement() {}
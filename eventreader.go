@@ -0,0 +1,40 @@
+package dogma
+
+import "context"
+
+// An EventReader provides read access to the events recorded by an
+// application, from outside the context of any message handler.
+//
+// It's intended for use by consumers that require a single ordered view of all
+// of an application's events, such as change-data-capture exporters.
+type EventReader interface {
+	// ReadEvents calls fn for each event recorded by an application, beginning
+	// with the event that occurs after the position p.
+	//
+	// k is the identity key of the [Application], as configured by its
+	// Configure() method. If p is empty, reading begins with the first event
+	// that the application recorded.
+	//
+	// The engine MUST supply events in a total order that's consistent with the
+	// order in which they were recorded by each handler, and with the causal
+	// relationships between them. Each call to ReadEvents() with the same
+	// arguments MUST observe the events in the same order.
+	//
+	// The engine MAY omit events recorded on a route that uses
+	// [WithEphemeralDelivery], if it didn't persist them durably.
+	//
+	// pos is the position of e. It's engine-defined; the caller SHOULD NOT
+	// infer any meaning from its content, but MAY persist it for use as p in a
+	// subsequent call.
+	//
+	// Reading stops when fn returns false or a non-nil error, when ctx is
+	// canceled, or when there are no more events. The engine MAY wait for
+	// further events to be recorded rather than returning. If fn returns a
+	// non-nil error, ReadEvents() returns that error.
+	ReadEvents(
+		ctx context.Context,
+		k string,
+		p []byte,
+		fn func(ctx context.Context, pos []byte, e Event) (bool, error),
+	) error
+}
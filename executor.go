@@ -1,6 +1,9 @@
 package dogma
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // A CommandExecutor executes a command from outside the context of any message
 // handler.
@@ -20,5 +23,192 @@ type CommandExecutor interface {
 }
 
 // ExecuteCommandOption is an option that affects the behavior of a call to the
-// ExecuteCommand() method of the [CommandExecutor] interface.
-type ExecuteCommandOption struct{}
+// ExecuteCommand() method of the [CommandExecutor], [ProcessEventScope] or
+// [ProcessTimeoutScope] interfaces.
+type ExecuteCommandOption interface {
+	isExecuteCommandOption()
+}
+
+// WithIdempotencyKey returns an option that prevents the engine from executing
+// more than one command with the same key.
+//
+// k is an application-defined key. It MUST NOT be empty. The key SHOULD be
+// derived from the command's purpose, rather than from the message or event
+// that caused it to be executed. For example, a process that executes a
+// "charge credit card" command in response to several distinct events could
+// use the process instance ID combined with a fixed string, such as
+// "<instance-id>/charge".
+//
+// If a command with the same key has already been executed within the same
+// application, the engine MUST NOT execute the command again and the call to
+// ExecuteCommand() MUST succeed.
+//
+// The engine MAY expire a key after an engine-defined retention period, after
+// which a command with the same key is executed as normal. The engine SHOULD
+// document this period, and it SHOULD be long enough to cover the engine's own
+// retries. Applications that require deduplication over a longer period MUST
+// implement it themselves.
+//
+// Reusing a key for a different command is an application error. If the
+// command's type differs from that of the command already executed with the
+// same key, the engine MUST NOT execute the command and ExecuteCommand() MUST
+// return a non-nil error. The engine SHOULD do the same if it's able to detect
+// that the commands' content differs.
+func WithIdempotencyKey(k string) IdempotencyKeyOption {
+	if k == "" {
+		panic("idempotency key must not be empty")
+	}
+	return IdempotencyKeyOption{k}
+}
+
+// IdempotencyKeyOption is an [ExecuteCommandOption] that prevents the engine
+// from executing more than one command with the same key. See
+// [WithIdempotencyKey].
+type IdempotencyKeyOption struct{ key string }
+
+// Key returns the idempotency key.
+func (o IdempotencyKeyOption) Key() string {
+	return o.key
+}
+
+// WithResult returns an option that causes the engine to wait until the
+// command has been handled, and to store the value passed to the handler's
+// SetResult() method in *dst.
+//
+// dst MUST NOT be nil. If the handler does not call SetResult(), *dst is set to
+// nil. If ExecuteCommand() returns a non-nil error, the content of *dst is
+// undefined.
+//
+// Only the attempt that successfully handles the command determines the
+// result. The engine MUST discard any value set by an attempt that fails, such
+// as when HandleCommand() returns an error, before retrying the command.
+//
+// If the handler rejects the command, as per AggregateCommandScope.Reject(),
+// ExecuteCommand() returns a [CommandRejectedError] and *dst is not modified.
+//
+// If the command is not executed because a command with the same key has
+// already been executed, as per [WithIdempotencyKey], ExecuteCommand() MUST
+// report the outcome of the earlier command: its result, its rejection, or its
+// failure to execute. The engine MUST retain each outcome for as long as it
+// retains the idempotency key.
+//
+// A result is only defined for a command that's handled by a single instance.
+// If the command is routed to more than one aggregate instance, as per
+// [MultiInstanceCommandRouter], the engine MUST NOT handle the command and
+// ExecuteCommand() MUST return a non-nil error.
+//
+// It's only meaningful when passed to the ExecuteCommand() method of a
+// [CommandExecutor]. The engine SHOULD panic if it's passed to the
+// ExecuteCommand() method of a [ProcessEventScope] or [ProcessTimeoutScope].
+func WithResult(dst *any) ResultOption {
+	if dst == nil {
+		panic("result destination must not be nil")
+	}
+	return ResultOption{dst}
+}
+
+// ResultOption is an [ExecuteCommandOption] that causes the engine to wait
+// until the command has been handled and return its result. See [WithResult].
+type ResultOption struct{ dst *any }
+
+// Destination returns the location at which the engine stores the result.
+func (o ResultOption) Destination() *any {
+	return o.dst
+}
+
+// WithExpiry returns an option that prevents the engine from executing a
+// command after a specific time.
+//
+// It's intended for commands that represent intent that's worthless after a
+// certain time, such as locking in a price that's only valid for ten minutes.
+//
+// t MUST NOT be the zero-value. If the engine has not begun handling the
+// command by t, it MUST NOT handle it. An attempt to handle the command that
+// begins before t MAY complete after t. If a failed attempt is retried, the
+// retry is subject to the same expiry time.
+//
+// When the engine discards an expired command it MUST record a structured
+// diagnostic, such as a log entry or telemetry span with discrete attributes,
+// that includes the command's message ID and type, the expiry time, and the
+// time at which the engine discarded it. It MUST NOT represent the expiry as
+// an [Event].
+//
+// If the command expires before ExecuteCommand() returns, such as when t has
+// already passed or the command was executed with the [WithResult] option,
+// ExecuteCommand() returns a [CommandExpiredError]. Otherwise, the expiry is
+// visible only via the diagnostic.
+func WithExpiry(t time.Time) ExpiryOption {
+	if t.IsZero() {
+		panic("expiry time must not be zero")
+	}
+	return ExpiryOption{t}
+}
+
+// ExpiryOption is an [ExecuteCommandOption] that prevents the engine from
+// executing a command after a specific time. See [WithExpiry].
+type ExpiryOption struct{ t time.Time }
+
+// Expiry returns the time after which the command must not be executed.
+func (o ExpiryOption) Expiry() time.Time {
+	return o.t
+}
+
+// CommandRejectedError is returned by the ExecuteCommand() method of a
+// [CommandExecutor] when the command was rejected by its handler, as per
+// AggregateCommandScope.Reject(). It's only returned when the command is
+// executed with the [WithResult] option.
+type CommandRejectedError struct {
+	// Reason is the reason passed to Reject().
+	Reason error
+}
+
+func (e CommandRejectedError) Error() string {
+	return "command rejected: " + e.Reason.Error()
+}
+
+// Unwrap returns the reason that the command was rejected.
+func (e CommandRejectedError) Unwrap() error {
+	return e.Reason
+}
+
+// CommandExpiredError is returned by the ExecuteCommand() method of a
+// [CommandExecutor] when the command expired before it was handled, as per
+// [WithExpiry].
+type CommandExpiredError struct {
+	// Expiry is the time after which the command could not be executed.
+	Expiry time.Time
+}
+
+func (e CommandExpiredError) Error() string {
+	return "command expired at " + e.Expiry.Format(time.RFC3339)
+}
+
+// ValidateCommand validates c from outside the context of any message handler.
+//
+// It allows code that executes commands, such as an API layer, to reject
+// invalid input before calling [CommandExecutor.ExecuteCommand] without
+// implementing its own [CommandValidationScope].
+//
+// The scope passed to c's Validate() method reports [ProductionEnvironment],
+// such that the strictest validation rules apply. It returns ctx's error
+// without validating c if ctx is already canceled.
+//
+// The engine MUST still validate the command when it's executed.
+func ValidateCommand(ctx context.Context, c Command) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Validate(commandValidationScope{})
+}
+
+// commandValidationScope is the [CommandValidationScope] used by
+// [ValidateCommand].
+type commandValidationScope struct{}
+
+func (commandValidationScope) Environment() Environment {
+	return ProductionEnvironment
+}
+
+func (commandValidationScope) Validator() string {
+	return "dogma.ValidateCommand"
+}
@@ -0,0 +1,7 @@
+package dogma
+
+func (IdempotencyKeyOption) isExecuteCommandOption() {}
+func (ResultOption) isExecuteCommandOption()         {}
+func (ExpiryOption) isExecuteCommandOption()         {}
+
+func (commandValidationScope) reservedCommandValidationScope() {}
@@ -0,0 +1,142 @@
+package dogma_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/dogmatiq/dogma"
+)
+
+func TestWithIdempotencyKey(t *testing.T) {
+	t.Run("it returns an option with the given key", func(t *testing.T) {
+		if WithIdempotencyKey("<key>").Key() != "<key>" {
+			t.Fatal("unexpected key")
+		}
+	})
+
+	t.Run("it panics if the key is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithIdempotencyKey("")
+	})
+}
+
+func TestWithResult(t *testing.T) {
+	t.Run("it returns an option with the given destination", func(t *testing.T) {
+		var v any
+		if WithResult(&v).Destination() != &v {
+			t.Fatal("unexpected destination")
+		}
+	})
+
+	t.Run("it panics if the destination is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithResult(nil)
+	})
+}
+
+func TestWithExpiry(t *testing.T) {
+	t.Run("it returns an option with the given expiry time", func(t *testing.T) {
+		expiry := time.Now().Add(10 * time.Minute)
+
+		if !WithExpiry(expiry).Expiry().Equal(expiry) {
+			t.Fatal("unexpected expiry time")
+		}
+	})
+
+	t.Run("it panics if the expiry time is zero", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithExpiry(time.Time{})
+	})
+}
+
+func TestCommandRejectedError(t *testing.T) {
+	reason := errors.New("<reason>")
+	err := CommandRejectedError{reason}
+
+	if err.Error() != "command rejected: <reason>" {
+		t.Fatalf("unexpected error message: %q", err.Error())
+	}
+
+	if !errors.Is(err, reason) {
+		t.Fatal("expected error to wrap the reason")
+	}
+}
+
+func TestCommandExpiredError(t *testing.T) {
+	err := CommandExpiredError{
+		Expiry: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	if err.Error() != "command expired at 2030-01-02T03:04:05Z" {
+		t.Fatalf("unexpected error message: %q", err.Error())
+	}
+}
+
+type validatedCommand struct {
+	noPayloadCommand
+	fn func(CommandValidationScope) error
+}
+
+func (c validatedCommand) Validate(s CommandValidationScope) error {
+	return c.fn(s)
+}
+
+func TestValidateCommand(t *testing.T) {
+	t.Run("it returns the error from the command's Validate() method", func(t *testing.T) {
+		want := errors.New("<error>")
+
+		got := ValidateCommand(
+			context.Background(),
+			validatedCommand{
+				fn: func(s CommandValidationScope) error {
+					if s.Environment() != ProductionEnvironment {
+						t.Fatal("unexpected environment")
+					}
+
+					if s.Validator() == "" {
+						t.Fatal("expected a non-empty validator name")
+					}
+
+					return want
+				},
+			},
+		)
+
+		if got != want {
+			t.Fatalf("unexpected error: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("it returns the context error without validating if the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := ValidateCommand(
+			ctx,
+			validatedCommand{
+				fn: func(CommandValidationScope) error {
+					t.Fatal("unexpected call to Validate()")
+					return nil
+				},
+			},
+		)
+
+		if err != context.Canceled {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package dogma
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MessageField describes the evolution of a single field of a message's
+// underlying struct type, as declared by its "dogma" struct tag.
+//
+// The tag is a comma-separated list of the following items:
+//
+//   - added_in=<n>: the field was added in version n of the message's schema,
+//     where n is a positive integer
+//   - deprecated: the field is deprecated and SHOULD NOT be populated by new
+//     code
+//
+// For example:
+//
+//	type AccountOpened struct {
+//		AccountID string
+//		Nickname  string `dogma:"added_in=2"`
+//		Branch    string `dogma:"added_in=2,deprecated"`
+//	}
+//
+// Schema versions are application-defined. This module does not assign or
+// track versions; the application numbers the versions of each message type,
+// starting at 1, and increments the number whenever it changes the message's
+// fields.
+//
+// The tag allows tools such as schema generators and upcasters to share a
+// single source of truth about how a message type has evolved. It does not
+// affect the behavior of the engine.
+type MessageField struct {
+	// Name is the name of the Go struct field.
+	Name string
+
+	// AddedIn is the version of the message's schema in which the field was
+	// added. A value of zero means the field has existed since the first
+	// version.
+	AddedIn int
+
+	// IsDeprecated indicates whether the field is deprecated.
+	IsDeprecated bool
+}
+
+// FieldSchema returns a description of each exported field of t, in the order
+// in which they're declared.
+//
+// t MUST be a struct type, or a pointer to a struct type. It returns an error if
+// t is nil or not such a type, or if any field's "dogma" struct tag is
+// malformed.
+//
+// Embedded fields are not flattened. Each exported embedded field is described
+// as a single field, named after the embedded type. Call FieldSchema() on the
+// embedded type to describe its fields.
+func FieldSchema(t reflect.Type) ([]MessageField, error) {
+	if t == nil {
+		return nil, fmt.Errorf("type must not be nil")
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct type", t)
+	}
+
+	var fields []MessageField
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		field, err := parseFieldTag(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// parseFieldTag returns the [MessageField] described by f's "dogma" tag.
+func parseFieldTag(f reflect.StructField) (MessageField, error) {
+	field := MessageField{Name: f.Name}
+
+	tag, ok := f.Tag.Lookup("dogma")
+	if !ok || tag == "" {
+		return field, nil
+	}
+
+	for _, item := range strings.Split(tag, ",") {
+		k, v, hasValue := strings.Cut(item, "=")
+
+		switch {
+		case k == "added_in" && hasValue:
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return field, fmt.Errorf("invalid added_in version %q, expected a positive integer", v)
+			}
+			field.AddedIn = n
+
+		case k == "deprecated" && !hasValue:
+			field.IsDeprecated = true
+
+		default:
+			return field, fmt.Errorf("unrecognized dogma tag item %q", item)
+		}
+	}
+
+	return field, nil
+}
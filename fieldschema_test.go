@@ -0,0 +1,123 @@
+package dogma_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	. "github.com/dogmatiq/dogma"
+)
+
+func TestFieldSchema(t *testing.T) {
+	t.Run("it describes the exported fields of the struct", func(t *testing.T) {
+		type message struct {
+			A string
+			B string `dogma:"added_in=2"`
+			C string `dogma:"added_in=3,deprecated"`
+			d string `dogma:"added_in=4"`
+		}
+
+		want := []MessageField{
+			{Name: "A"},
+			{Name: "B", AddedIn: 2},
+			{Name: "C", AddedIn: 3, IsDeprecated: true},
+		}
+
+		for _, rt := range []reflect.Type{
+			reflect.TypeFor[message](),
+			reflect.TypeFor[*message](),
+		} {
+			got, err := FieldSchema(rt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(got, want) {
+				t.Fatalf("unexpected fields: got %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("it does not flatten embedded structs", func(t *testing.T) {
+		type Embedded struct {
+			A string
+		}
+
+		type message struct {
+			Embedded `dogma:"added_in=2"`
+			B        string
+		}
+
+		got, err := FieldSchema(reflect.TypeFor[message]())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []MessageField{
+			{Name: "Embedded", AddedIn: 2},
+			{Name: "B"},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("unexpected fields: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("it returns an error if the type is nil", func(t *testing.T) {
+		if _, err := FieldSchema(nil); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("it returns an error if the type is not a struct", func(t *testing.T) {
+		if _, err := FieldSchema(reflect.TypeFor[string]()); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("it returns an error if a tag is malformed", func(t *testing.T) {
+		cases := []struct {
+			Name string
+			Type reflect.Type
+		}{
+			{
+				"non-numeric version",
+				reflect.TypeFor[struct {
+					A string `dogma:"added_in=x"`
+				}](),
+			},
+			{
+				"non-positive version",
+				reflect.TypeFor[struct {
+					A string `dogma:"added_in=0"`
+				}](),
+			},
+			{
+				"missing version",
+				reflect.TypeFor[struct {
+					A string `dogma:"added_in"`
+				}](),
+			},
+			{
+				"deprecated with value",
+				reflect.TypeFor[struct {
+					A string `dogma:"deprecated=true"`
+				}](),
+			},
+			{
+				"unrecognized item",
+				reflect.TypeFor[struct {
+					A string `dogma:"removed_in=3"`
+				}](),
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.Name, func(t *testing.T) {
+				if _, err := FieldSchema(c.Type); err == nil {
+					t.Fatal("expected an error")
+				}
+			})
+		}
+	})
+}
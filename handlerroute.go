@@ -1,5 +1,7 @@
 package dogma
 
+import "time"
+
 // ViaAggregate configures an [Application] to route messages to and from the
 // specified [AggregateMessageHandler]. It is used as an argument to the
 // Routes() method of [ApplicationConfigurer].
@@ -10,8 +12,12 @@ package dogma
 //
 // [Event] messages recorded by h using an [AggregateCommandScope] are routed to
 // other handlers according to their route configurations.
-func ViaAggregate(h AggregateMessageHandler, _ ...ViaAggregateOption) ViaAggregateRoute {
-	return ViaAggregateRoute{h}
+func ViaAggregate(h AggregateMessageHandler, opts ...ViaAggregateOption) ViaAggregateRoute {
+	r := ViaAggregateRoute{Handler: h}
+	for _, opt := range opts {
+		opt.applyToViaAggregate(&r)
+	}
+	return r
 }
 
 // ViaProcess configures an [Application] to route messages to and from the
@@ -27,8 +33,12 @@ func ViaAggregate(h AggregateMessageHandler, _ ...ViaAggregateOption) ViaAggrega
 // configurations.
 //
 // [Timeout] messages are always routed back to h itself.
-func ViaProcess(h ProcessMessageHandler, _ ...ViaProcessOption) ViaProcessRoute {
-	return ViaProcessRoute{h}
+func ViaProcess(h ProcessMessageHandler, opts ...ViaProcessOption) ViaProcessRoute {
+	r := ViaProcessRoute{Handler: h}
+	for _, opt := range opts {
+		opt.applyToViaProcess(&r)
+	}
+	return r
 }
 
 // ViaIntegration configures an [Application] to route messages to and from the
@@ -41,8 +51,12 @@ func ViaProcess(h ProcessMessageHandler, _ ...ViaProcessOption) ViaProcessRoute
 //
 // [Event] messages recorded by h using an [IntegrationCommandScope] are routed
 // to other handlers according to their route configurations.
-func ViaIntegration(h IntegrationMessageHandler, _ ...ViaIntegrationOption) ViaIntegrationRoute {
-	return ViaIntegrationRoute{h}
+func ViaIntegration(h IntegrationMessageHandler, opts ...ViaIntegrationOption) ViaIntegrationRoute {
+	r := ViaIntegrationRoute{Handler: h}
+	for _, opt := range opts {
+		opt.applyToViaIntegration(&r)
+	}
+	return r
 }
 
 // ViaProjection configures an [Application] to route messages to the specified
@@ -52,8 +66,12 @@ func ViaIntegration(h IntegrationMessageHandler, _ ...ViaIntegrationOption) ViaI
 // [Event] messages recorded using an [AggregateCommandScope] or
 // [IntegrationCommandScope] are routed to h if it has a [HandlesEvent] route
 // for that event type.
-func ViaProjection(h ProjectionMessageHandler, _ ...ViaProjectionOption) ViaProjectionRoute {
-	return ViaProjectionRoute{h}
+func ViaProjection(h ProjectionMessageHandler, opts ...ViaProjectionOption) ViaProjectionRoute {
+	r := ViaProjectionRoute{Handler: h}
+	for _, opt := range opts {
+		opt.applyToViaProjection(&r)
+	}
+	return r
 }
 
 type (
@@ -65,35 +83,521 @@ type (
 
 	// ViaAggregateRoute describes an [AggregateMessageHandler] that is to be
 	// registered with an [Application].
-	ViaAggregateRoute struct{ Handler AggregateMessageHandler }
+	ViaAggregateRoute struct {
+		Handler AggregateMessageHandler
+
+		// MessageQuota is the maximum number of messages that the handler may
+		// produce within a single scope, as per [WithMessageQuota]. A value of
+		// zero means there is no limit.
+		MessageQuota int
+
+		// IsDisabled indicates whether the application has disabled the
+		// handler, as per [EnabledWhen].
+		IsDisabled bool
+
+		// Labels is a set of key/value pairs that describe the handler, as per
+		// [WithHandlerLabel].
+		Labels map[string]string
+
+		// Replaces describes the handler that this handler replaces, as per
+		// [Replaces]. The zero-value means the handler does not replace
+		// another.
+		Replaces HandlerReplacement
+	}
 
 	// ViaProcessRoute describes a [ProcessMessageHandler] that is to be
 	// registered with an [Application].
-	ViaProcessRoute struct{ Handler ProcessMessageHandler }
+	ViaProcessRoute struct {
+		Handler ProcessMessageHandler
+
+		// MessageQuota is the maximum number of messages that the handler may
+		// produce within a single scope, as per [WithMessageQuota]. A value of
+		// zero means there is no limit.
+		MessageQuota int
+
+		// RootCompression is the name of the compression algorithm that the
+		// engine should use to compress the binary representation of the
+		// handler's process roots, as per [WithRootCompression]. An empty
+		// string means no compression is requested.
+		RootCompression string
+
+		// TimeoutResolution is the coarsest precision with which the engine
+		// may deliver the handler's timeouts, as per [WithTimeoutResolution].
+		// A value of zero means no resolution is specified.
+		TimeoutResolution time.Duration
+
+		// MaxRootSize is the maximum size, in bytes, of the binary
+		// representation of the handler's process roots, as per
+		// [WithMaxRootSize]. A value of zero means there is no limit.
+		MaxRootSize int
+
+		// IsDisabled indicates whether the application has disabled the
+		// handler, as per [EnabledWhen].
+		IsDisabled bool
+
+		// Labels is a set of key/value pairs that describe the handler, as per
+		// [WithHandlerLabel].
+		Labels map[string]string
+
+		// Replaces describes the handler that this handler replaces, as per
+		// [Replaces]. The zero-value means the handler does not replace
+		// another.
+		Replaces HandlerReplacement
+	}
 
 	// ViaIntegrationRoute describes an [IntegrationMessageHandler] that is
 	// to be registered with an [Application].
-	ViaIntegrationRoute struct{ Handler IntegrationMessageHandler }
+	ViaIntegrationRoute struct {
+		Handler IntegrationMessageHandler
+
+		// MessageQuota is the maximum number of messages that the handler may
+		// produce within a single scope, as per [WithMessageQuota]. A value of
+		// zero means there is no limit.
+		MessageQuota int
+
+		// RateLimit is the maximum number of commands that the engine may
+		// dispatch to the handler within each RateLimitPeriod, as per
+		// [WithRateLimit]. A value of zero means there is no limit.
+		RateLimit int
+
+		// RateLimitPeriod is the period of time to which RateLimit applies.
+		RateLimitPeriod time.Duration
+
+		// IsDisabled indicates whether the application has disabled the
+		// handler, as per [EnabledWhen].
+		IsDisabled bool
+
+		// Labels is a set of key/value pairs that describe the handler, as per
+		// [WithHandlerLabel].
+		Labels map[string]string
+
+		// Replaces describes the handler that this handler replaces, as per
+		// [Replaces]. The zero-value means the handler does not replace
+		// another.
+		Replaces HandlerReplacement
+	}
 
 	// ViaProjectionRoute describes a [ProjectionMessageHandler] that is to be
 	// registered with an [Application].
-	ViaProjectionRoute struct{ Handler ProjectionMessageHandler }
+	ViaProjectionRoute struct {
+		Handler ProjectionMessageHandler
+
+		// CheckpointBatchSize is the maximum number of events that the engine
+		// may handle before advancing the handler's checkpoint, as per
+		// [WithCheckpointBatching]. A value of zero means the checkpoint is
+		// advanced after every event.
+		CheckpointBatchSize int
+
+		// CatchUpPriority is the relative priority of the projection when the
+		// engine must bring several projections up-to-date, as per
+		// [WithCatchUpPriority].
+		CatchUpPriority int
+
+		// DeliveryPolicy describes how the engine delivers events to the
+		// handler, as per [WithDeliveryPolicy]. A nil value means the policy
+		// configured by the handler's Configure() method is used.
+		DeliveryPolicy ProjectionDeliveryPolicy
+
+		// IsDisabled indicates whether the application has disabled the
+		// handler, as per [EnabledWhen].
+		IsDisabled bool
+
+		// Labels is a set of key/value pairs that describe the handler, as per
+		// [WithHandlerLabel].
+		Labels map[string]string
+
+		// Replaces describes the handler that this handler replaces, as per
+		// [Replaces]. The zero-value means the handler does not replace
+		// another.
+		Replaces HandlerReplacement
+	}
 )
 
+// HandlerReplacement describes a handler that is replaced by another handler at
+// a specific point in time. See [Replaces].
+type HandlerReplacement struct {
+	// Key is the identity key of the replaced handler.
+	Key string
+
+	// Cutover is the time at which the replacement handler takes over from
+	// the replaced handler.
+	Cutover time.Time
+}
+
 type (
-	// ViaAggregateOption is an option that affects the behavior of a call to
-	// the RegisterAggregate() method of the [ApplicationConfigurer] interface.
-	ViaAggregateOption struct{}
+	// ViaAggregateOption is an option that affects the behavior of the route
+	// returned by [ViaAggregate].
+	ViaAggregateOption interface {
+		applyToViaAggregate(*ViaAggregateRoute)
+	}
 
-	// ViaProcessOption is an option that affects the behavior of a call to
-	// the RegisterProcess() method of the [ApplicationConfigurer] interface.
-	ViaProcessOption struct{}
+	// ViaProcessOption is an option that affects the behavior of the route
+	// returned by [ViaProcess].
+	ViaProcessOption interface {
+		applyToViaProcess(*ViaProcessRoute)
+	}
 
-	// ViaIntegrationOption is an option that affects the behavior of a call to
-	// the RegisterIntegration() method of the [ApplicationConfigurer] interface.
-	ViaIntegrationOption struct{}
+	// ViaIntegrationOption is an option that affects the behavior of the
+	// route returned by [ViaIntegration].
+	ViaIntegrationOption interface {
+		applyToViaIntegration(*ViaIntegrationRoute)
+	}
 
-	// ViaProjectionOption is an option that affects the behavior of a call to
-	// the RegisterProjection() method of the [ApplicationConfigurer] interface.
-	ViaProjectionOption struct{}
+	// ViaProjectionOption is an option that affects the behavior of the route
+	// returned by [ViaProjection].
+	ViaProjectionOption interface {
+		applyToViaProjection(*ViaProjectionRoute)
+	}
 )
+
+// EnabledWhen returns an option that disables a handler unless fn returns true.
+//
+// fn is called once, when the option is created, such that the decision is made
+// when the application is composed. It allows the application to disable a
+// handler based on its environment, such as a feature flag or the absence of
+// credentials for an external system, while the handler still appears in the
+// application's configuration.
+//
+// If the option is used more than once, the handler is disabled unless every
+// condition is met.
+//
+// The engine MUST treat a handler that's disabled by this option the same way
+// it treats a handler that calls Disable() on its configurer.
+func EnabledWhen(fn func() bool) EnabledWhenOption {
+	if fn == nil {
+		panic("enablement predicate must not be nil")
+	}
+	return EnabledWhenOption{fn()}
+}
+
+// EnabledWhenOption is an option that disables a handler unless a condition is
+// met. See [EnabledWhen].
+type EnabledWhenOption struct{ enabled bool }
+
+func (o EnabledWhenOption) applyToViaAggregate(r *ViaAggregateRoute) {
+	r.IsDisabled = r.IsDisabled || !o.enabled
+}
+
+func (o EnabledWhenOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.IsDisabled = r.IsDisabled || !o.enabled
+}
+
+func (o EnabledWhenOption) applyToViaIntegration(r *ViaIntegrationRoute) {
+	r.IsDisabled = r.IsDisabled || !o.enabled
+}
+
+func (o EnabledWhenOption) applyToViaProjection(r *ViaProjectionRoute) {
+	r.IsDisabled = r.IsDisabled || !o.enabled
+}
+
+// WithHandlerLabel returns an option that attaches a key/value pair to a
+// handler.
+//
+// k is the label's key. It MUST NOT be empty. If the same key is specified
+// multiple times, the last value takes precedence. v is the label's value.
+//
+// Labels do not affect how the engine handles messages. The engine SHOULD
+// propagate handler labels to the observability data that it produces for the
+// handler, such as metrics and traces. Labels are intended to describe
+// operational concerns, such as the team responsible for the handler, its
+// service-level tier or its cost center.
+func WithHandlerLabel(k, v string) HandlerLabelOption {
+	if k == "" {
+		panic("label key must not be empty")
+	}
+	return HandlerLabelOption{k, v}
+}
+
+// HandlerLabelOption is an option that attaches a key/value pair to a handler.
+// See [WithHandlerLabel].
+type HandlerLabelOption struct{ k, v string }
+
+func (o HandlerLabelOption) applyToViaAggregate(r *ViaAggregateRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o HandlerLabelOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o HandlerLabelOption) applyToViaIntegration(r *ViaIntegrationRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o HandlerLabelOption) applyToViaProjection(r *ViaProjectionRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+// Replaces returns an option that declares that a handler replaces another
+// handler of the same type, allowing a live migration from one handler to the
+// other.
+//
+// k is the identity key of the replaced handler. It MUST NOT be empty, and MUST
+// NOT be the key of the replacing handler. cutover is the time at which the
+// replacement takes over. It MUST NOT be the zero-value.
+//
+// The engine MUST route messages that occurred before cutover to the replaced
+// handler, and messages that occur at or after cutover to the replacement
+// handler. For events, the time at which the event was recorded determines
+// which handler receives it; for commands and timeouts, the time at which the
+// engine begins to handle the message. The engine SHOULD report an error if the
+// replaced handler is absent from the application before cutover.
+//
+// Once all messages that occurred before cutover have been handled, the
+// replaced handler MAY be removed from the application.
+func Replaces(k string, cutover time.Time) ReplacesOption {
+	if k == "" {
+		panic("replaced handler key must not be empty")
+	}
+	if cutover.IsZero() {
+		panic("cutover time must not be zero")
+	}
+	return ReplacesOption{HandlerReplacement{k, cutover}}
+}
+
+// ReplacesOption is an option that declares that a handler replaces another
+// handler. See [Replaces].
+type ReplacesOption struct{ r HandlerReplacement }
+
+func (o ReplacesOption) applyToViaAggregate(r *ViaAggregateRoute) {
+	r.Replaces = o.r
+}
+
+func (o ReplacesOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.Replaces = o.r
+}
+
+func (o ReplacesOption) applyToViaIntegration(r *ViaIntegrationRoute) {
+	r.Replaces = o.r
+}
+
+func (o ReplacesOption) applyToViaProjection(r *ViaProjectionRoute) {
+	r.Replaces = o.r
+}
+
+// WithMessageQuota returns an option that limits the number of messages that a
+// handler may produce within a single scope.
+//
+// n is the maximum total number of events recorded, commands executed and
+// timeouts scheduled within a single call to one of the handler's
+// message-handling methods. It MUST be positive.
+//
+// The quota protects the engine from handlers that produce an unbounded number
+// of messages due to a programming error. If the handler exceeds the quota the
+// engine MUST NOT produce any of the messages and SHOULD report the failure
+// prominently, such as by panicking from within the scope method call that
+// exceeded the quota.
+func WithMessageQuota(n int) MessageQuotaOption {
+	if n <= 0 {
+		panic("message quota must be positive")
+	}
+	return MessageQuotaOption{n}
+}
+
+// MessageQuotaOption is an option that limits the number of messages that a
+// handler may produce within a single scope. See [WithMessageQuota].
+type MessageQuotaOption struct{ n int }
+
+func (o MessageQuotaOption) applyToViaAggregate(r *ViaAggregateRoute) {
+	r.MessageQuota = o.n
+}
+
+func (o MessageQuotaOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.MessageQuota = o.n
+}
+
+func (o MessageQuotaOption) applyToViaIntegration(r *ViaIntegrationRoute) {
+	r.MessageQuota = o.n
+}
+
+// WithRootCompression returns an option that requests compression of the binary
+// representation of a process's roots when they are persisted or transmitted
+// by the engine.
+//
+// alg is the name of the compression algorithm, using the names in the IANA
+// HTTP Content Coding Registry, such as "gzip", "br" or "zstd". It MUST NOT be
+// empty.
+//
+// The engine SHOULD compress process roots using the requested algorithm. If
+// the engine does not support the algorithm it MAY use a different algorithm
+// or no compression at all.
+func WithRootCompression(alg string) RootCompressionOption {
+	if alg == "" {
+		panic("compression algorithm must not be empty")
+	}
+	return RootCompressionOption{alg}
+}
+
+// RootCompressionOption is an option that requests compression of a process's
+// roots. See [WithRootCompression].
+type RootCompressionOption struct{ alg string }
+
+func (o RootCompressionOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.RootCompression = o.alg
+}
+
+// WithMaxRootSize returns an option that limits the size of the binary
+// representation of a process's roots.
+//
+// n is the maximum size in bytes, measured before any compression requested by
+// [WithRootCompression] is applied. It MUST be positive.
+//
+// The limit guards against process roots that grow without bound, such as by
+// accumulating entries in a list that's never pruned, before they exceed the
+// limits of the engine's persistence mechanism. If a root exceeds the limit
+// after handling a message the engine MUST NOT persist the root or any of the
+// messages produced by the handler, and SHOULD report an error that identifies
+// the handler, the instance and the size of the root.
+func WithMaxRootSize(n int) MaxRootSizeOption {
+	if n <= 0 {
+		panic("maximum root size must be positive")
+	}
+	return MaxRootSizeOption{n}
+}
+
+// MaxRootSizeOption is an option that limits the size of the binary
+// representation of a process's roots. See [WithMaxRootSize].
+type MaxRootSizeOption struct{ n int }
+
+func (o MaxRootSizeOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.MaxRootSize = o.n
+}
+
+// WithCheckpointBatching returns an option that allows the engine to advance a
+// projection's checkpoint once per batch of events, rather than after every
+// event.
+//
+// n is the maximum number of events in each batch. It MUST be positive.
+//
+// By using this option the handler declares that it tolerates the engine
+// passing it up to n events that it has already handled, such as when the
+// engine recovers from a failure that occurred part-way through a batch. It's
+// intended for low-value projections, such as analytics, where reducing write
+// amplification is more important than the cost of reprocessing events.
+//
+// The engine MAY ignore this option.
+func WithCheckpointBatching(n int) CheckpointBatchingOption {
+	if n <= 0 {
+		panic("checkpoint batch size must be positive")
+	}
+	return CheckpointBatchingOption{n}
+}
+
+// CheckpointBatchingOption is an option that allows the engine to advance a
+// projection's checkpoint once per batch of events. See
+// [WithCheckpointBatching].
+type CheckpointBatchingOption struct{ n int }
+
+func (o CheckpointBatchingOption) applyToViaProjection(r *ViaProjectionRoute) {
+	r.CheckpointBatchSize = o.n
+}
+
+// WithCatchUpPriority returns an option that hints at the relative priority of
+// a projection when the engine must bring several projections up-to-date, such
+// as after a deployment or when rebuilding projections from scratch.
+//
+// Projections with a higher priority SHOULD be brought up-to-date before those
+// with a lower priority. For example, an application may give projections that
+// serve customer-facing views a higher priority than those used for internal
+// analytics. The default priority is zero. Negative priorities are permitted.
+//
+// The priority is a hint only. The engine MAY ignore it.
+func WithCatchUpPriority(n int) CatchUpPriorityOption {
+	return CatchUpPriorityOption{n}
+}
+
+// CatchUpPriorityOption is an option that hints at the relative priority of a
+// projection when the engine must bring several projections up-to-date. See
+// [WithCatchUpPriority].
+type CatchUpPriorityOption struct{ n int }
+
+func (o CatchUpPriorityOption) applyToViaProjection(r *ViaProjectionRoute) {
+	r.CatchUpPriority = o.n
+}
+
+// WithDeliveryPolicy returns an option that describes how the engine delivers
+// events to a projection, overriding the policy configured by the handler's
+// Configure() method.
+//
+// It allows the application to declare whether the projection maintains shared
+// state, in which case each event is delivered to a single instance of the
+// application ([UnicastProjectionDeliveryPolicy]), or per-node state such as an
+// in-memory cache, in which case each event is delivered to every instance
+// ([BroadcastProjectionDeliveryPolicy]).
+//
+// p MUST NOT be nil.
+func WithDeliveryPolicy(p ProjectionDeliveryPolicy) DeliveryPolicyOption {
+	if p == nil {
+		panic("delivery policy must not be nil")
+	}
+	return DeliveryPolicyOption{p}
+}
+
+// DeliveryPolicyOption is an option that describes how the engine delivers
+// events to a projection. See [WithDeliveryPolicy].
+type DeliveryPolicyOption struct{ p ProjectionDeliveryPolicy }
+
+func (o DeliveryPolicyOption) applyToViaProjection(r *ViaProjectionRoute) {
+	r.DeliveryPolicy = o.p
+}
+
+// WithTimeoutResolution returns an option that declares the coarsest precision
+// with which the engine may deliver a process's timeouts.
+//
+// d is the maximum acceptable delay between the time at which a timeout is
+// scheduled to occur and the time at which it's delivered, under normal
+// operating conditions. It MUST be positive. For example, a process that sends
+// reminders may accept a resolution of one minute, whereas a process that
+// closes auctions may require a resolution of one second.
+//
+// The engine MAY use this information to batch its scans for due timeouts. It
+// MUST NOT deliver a timeout before its scheduled time regardless of the
+// resolution.
+func WithTimeoutResolution(d time.Duration) TimeoutResolutionOption {
+	if d <= 0 {
+		panic("timeout resolution must be positive")
+	}
+	return TimeoutResolutionOption{d}
+}
+
+// TimeoutResolutionOption is an option that declares the coarsest precision
+// with which the engine may deliver a process's timeouts. See
+// [WithTimeoutResolution].
+type TimeoutResolutionOption struct{ d time.Duration }
+
+func (o TimeoutResolutionOption) applyToViaProcess(r *ViaProcessRoute) {
+	r.TimeoutResolution = o.d
+}
+
+// WithRateLimit returns an option that limits the rate at which the engine
+// dispatches commands to an integration.
+//
+// n is the maximum number of commands dispatched within any period of length
+// per. Both values MUST be positive.
+//
+// It allows the application to declare the throughput ceiling of the external
+// system that the integration interacts with. The engine SHOULD delay the
+// dispatch of commands that would exceed the limit, rather than relying on the
+// handler returning errors and consuming retry attempts. The limit applies to
+// the handler as a whole, across all instances of the application.
+func WithRateLimit(n int, per time.Duration) RateLimitOption {
+	if n <= 0 {
+		panic("rate limit must be positive")
+	}
+	if per <= 0 {
+		panic("rate limit period must be positive")
+	}
+	return RateLimitOption{n, per}
+}
+
+// RateLimitOption is an option that limits the rate at which the engine
+// dispatches commands to an integration. See [WithRateLimit].
+type RateLimitOption struct {
+	n   int
+	per time.Duration
+}
+
+func (o RateLimitOption) applyToViaIntegration(r *ViaIntegrationRoute) {
+	r.RateLimit = o.n
+	r.RateLimitPeriod = o.per
+}
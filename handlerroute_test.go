@@ -1,7 +1,9 @@
 package dogma_test
 
 import (
+	"maps"
 	"testing"
+	"time"
 
 	. "github.com/dogmatiq/dogma"
 )
@@ -49,3 +51,361 @@ func TestViaProjection(t *testing.T) {
 		t.Fatal("unexpected handler")
 	}
 }
+
+func TestEnabledWhen(t *testing.T) {
+	type (
+		aggregate   struct{ AggregateMessageHandler }
+		process     struct{ ProcessMessageHandler }
+		integration struct{ IntegrationMessageHandler }
+		projection  struct{ ProjectionMessageHandler }
+	)
+
+	enabled := EnabledWhen(func() bool { return true })
+	disabled := EnabledWhen(func() bool { return false })
+
+	t.Run("it disables the handler if the predicate returns false", func(t *testing.T) {
+		if !ViaAggregate(&aggregate{}, disabled).IsDisabled {
+			t.Fatal("expected aggregate to be disabled")
+		}
+
+		if !ViaProcess(&process{}, disabled).IsDisabled {
+			t.Fatal("expected process to be disabled")
+		}
+
+		if !ViaIntegration(&integration{}, disabled).IsDisabled {
+			t.Fatal("expected integration to be disabled")
+		}
+
+		if !ViaProjection(&projection{}, disabled).IsDisabled {
+			t.Fatal("expected projection to be disabled")
+		}
+	})
+
+	t.Run("it does not disable the handler if the predicate returns true", func(t *testing.T) {
+		if ViaAggregate(&aggregate{}, enabled).IsDisabled {
+			t.Fatal("did not expect aggregate to be disabled")
+		}
+
+		if ViaProcess(&process{}, enabled).IsDisabled {
+			t.Fatal("did not expect process to be disabled")
+		}
+
+		if ViaIntegration(&integration{}, enabled).IsDisabled {
+			t.Fatal("did not expect integration to be disabled")
+		}
+
+		if ViaProjection(&projection{}, enabled).IsDisabled {
+			t.Fatal("did not expect projection to be disabled")
+		}
+	})
+
+	t.Run("it disables the handler if any of several predicates returns false", func(t *testing.T) {
+		if !ViaAggregate(&aggregate{}, disabled, enabled).IsDisabled {
+			t.Fatal("expected aggregate to be disabled")
+		}
+
+		if !ViaProcess(&process{}, disabled, enabled).IsDisabled {
+			t.Fatal("expected process to be disabled")
+		}
+
+		if !ViaIntegration(&integration{}, enabled, disabled).IsDisabled {
+			t.Fatal("expected integration to be disabled")
+		}
+
+		if !ViaProjection(&projection{}, disabled, enabled).IsDisabled {
+			t.Fatal("expected projection to be disabled")
+		}
+
+		if ViaAggregate(&aggregate{}, enabled, enabled).IsDisabled {
+			t.Fatal("did not expect aggregate to be disabled")
+		}
+	})
+
+	t.Run("it panics if the predicate is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		EnabledWhen(nil)
+	})
+}
+
+func TestWithHandlerLabel(t *testing.T) {
+	type (
+		aggregate   struct{ AggregateMessageHandler }
+		process     struct{ ProcessMessageHandler }
+		integration struct{ IntegrationMessageHandler }
+		projection  struct{ ProjectionMessageHandler }
+	)
+
+	t.Run("it sets the labels on the route", func(t *testing.T) {
+		opts := []HandlerLabelOption{
+			WithHandlerLabel("team", "<team>"),
+			WithHandlerLabel("tier", "<tier-1>"),
+			WithHandlerLabel("tier", "<tier-2>"),
+		}
+
+		want := map[string]string{
+			"team": "<team>",
+			"tier": "<tier-2>",
+		}
+
+		check := func(t *testing.T, got map[string]string) {
+			t.Helper()
+			if !maps.Equal(got, want) {
+				t.Fatalf("unexpected labels: %v", got)
+			}
+		}
+
+		check(t, ViaAggregate(&aggregate{}, opts[0], opts[1], opts[2]).Labels)
+		check(t, ViaProcess(&process{}, opts[0], opts[1], opts[2]).Labels)
+		check(t, ViaIntegration(&integration{}, opts[0], opts[1], opts[2]).Labels)
+		check(t, ViaProjection(&projection{}, opts[0], opts[1], opts[2]).Labels)
+	})
+
+	t.Run("it panics if the key is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithHandlerLabel("", "<value>")
+	})
+}
+
+func TestReplaces(t *testing.T) {
+	type (
+		aggregate   struct{ AggregateMessageHandler }
+		process     struct{ ProcessMessageHandler }
+		integration struct{ IntegrationMessageHandler }
+		projection  struct{ ProjectionMessageHandler }
+	)
+
+	t.Run("it sets the replaced handler on the route", func(t *testing.T) {
+		cutover := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		opt := Replaces("<key>", cutover)
+
+		want := HandlerReplacement{
+			Key:     "<key>",
+			Cutover: cutover,
+		}
+
+		if ViaAggregate(&aggregate{}, opt).Replaces != want {
+			t.Fatal("unexpected replacement on aggregate route")
+		}
+
+		if ViaProcess(&process{}, opt).Replaces != want {
+			t.Fatal("unexpected replacement on process route")
+		}
+
+		if ViaIntegration(&integration{}, opt).Replaces != want {
+			t.Fatal("unexpected replacement on integration route")
+		}
+
+		if ViaProjection(&projection{}, opt).Replaces != want {
+			t.Fatal("unexpected replacement on projection route")
+		}
+	})
+
+	t.Run("it panics if the key is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		Replaces("", time.Now())
+	})
+
+	t.Run("it panics if the cutover time is zero", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		Replaces("<key>", time.Time{})
+	})
+}
+
+func TestWithMessageQuota(t *testing.T) {
+	type (
+		aggregate   struct{ AggregateMessageHandler }
+		process     struct{ ProcessMessageHandler }
+		integration struct{ IntegrationMessageHandler }
+	)
+
+	t.Run("it sets the quota on the route", func(t *testing.T) {
+		opt := WithMessageQuota(10)
+
+		if ViaAggregate(&aggregate{}, opt).MessageQuota != 10 {
+			t.Fatal("unexpected quota on aggregate route")
+		}
+
+		if ViaProcess(&process{}, opt).MessageQuota != 10 {
+			t.Fatal("unexpected quota on process route")
+		}
+
+		if ViaIntegration(&integration{}, opt).MessageQuota != 10 {
+			t.Fatal("unexpected quota on integration route")
+		}
+	})
+
+	t.Run("it panics if the quota is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithMessageQuota(0)
+	})
+}
+
+func TestWithRootCompression(t *testing.T) {
+	type process struct{ ProcessMessageHandler }
+
+	t.Run("it sets the compression algorithm on the route", func(t *testing.T) {
+		r := ViaProcess(&process{}, WithRootCompression("zstd"))
+
+		if r.RootCompression != "zstd" {
+			t.Fatal("unexpected compression algorithm")
+		}
+	})
+
+	t.Run("it panics if the algorithm is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRootCompression("")
+	})
+}
+
+func TestWithMaxRootSize(t *testing.T) {
+	type process struct{ ProcessMessageHandler }
+
+	t.Run("it sets the maximum root size on the route", func(t *testing.T) {
+		r := ViaProcess(&process{}, WithMaxRootSize(1024))
+
+		if r.MaxRootSize != 1024 {
+			t.Fatal("unexpected maximum root size")
+		}
+	})
+
+	t.Run("it panics if the size is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithMaxRootSize(0)
+	})
+}
+
+func TestWithCheckpointBatching(t *testing.T) {
+	type projection struct{ ProjectionMessageHandler }
+
+	t.Run("it sets the batch size on the route", func(t *testing.T) {
+		r := ViaProjection(&projection{}, WithCheckpointBatching(100))
+
+		if r.CheckpointBatchSize != 100 {
+			t.Fatal("unexpected batch size")
+		}
+	})
+
+	t.Run("it panics if the batch size is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithCheckpointBatching(0)
+	})
+}
+
+func TestWithCatchUpPriority(t *testing.T) {
+	type projection struct{ ProjectionMessageHandler }
+
+	r := ViaProjection(&projection{}, WithCatchUpPriority(10))
+
+	if r.CatchUpPriority != 10 {
+		t.Fatal("unexpected priority")
+	}
+}
+
+func TestWithDeliveryPolicy(t *testing.T) {
+	type projection struct{ ProjectionMessageHandler }
+
+	t.Run("it sets the delivery policy on the route", func(t *testing.T) {
+		p := BroadcastProjectionDeliveryPolicy{PrimaryFirst: true}
+		r := ViaProjection(&projection{}, WithDeliveryPolicy(p))
+
+		if r.DeliveryPolicy != p {
+			t.Fatal("unexpected delivery policy")
+		}
+	})
+
+	t.Run("it panics if the policy is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithDeliveryPolicy(nil)
+	})
+}
+
+func TestWithTimeoutResolution(t *testing.T) {
+	type process struct{ ProcessMessageHandler }
+
+	t.Run("it sets the resolution on the route", func(t *testing.T) {
+		r := ViaProcess(&process{}, WithTimeoutResolution(time.Minute))
+
+		if r.TimeoutResolution != time.Minute {
+			t.Fatal("unexpected resolution")
+		}
+	})
+
+	t.Run("it panics if the resolution is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithTimeoutResolution(0)
+	})
+}
+
+func TestWithRateLimit(t *testing.T) {
+	type integration struct{ IntegrationMessageHandler }
+
+	t.Run("it sets the rate limit on the route", func(t *testing.T) {
+		r := ViaIntegration(&integration{}, WithRateLimit(100, time.Second))
+
+		if r.RateLimit != 100 {
+			t.Fatal("unexpected rate limit")
+		}
+
+		if r.RateLimitPeriod != time.Second {
+			t.Fatal("unexpected rate limit period")
+		}
+	})
+
+	t.Run("it panics if the limit is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRateLimit(0, time.Second)
+	})
+
+	t.Run("it panics if the period is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRateLimit(100, 0)
+	})
+}
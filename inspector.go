@@ -0,0 +1,22 @@
+package dogma
+
+import "context"
+
+// A ProcessInspector provides read-only access to the state of process
+// instances from outside the context of any message handler.
+//
+// It's intended for use by diagnostic and support tooling, such as an endpoint
+// that reports the current state of a specific process instance.
+type ProcessInspector interface {
+	// InspectProcess returns the current root of a specific process instance.
+	//
+	// k is the identity key of the [ProcessMessageHandler], as configured by
+	// its Configure() method. id is the ID of the process instance, as
+	// returned by RouteEventToInstance().
+	//
+	// If ok is false, the instance has not begun or has already ended.
+	//
+	// The caller MUST NOT modify the returned root. The engine MAY return a
+	// root that's shared with handlers, or a copy of it.
+	InspectProcess(ctx context.Context, k, id string) (r ProcessRoot, ok bool, err error)
+}
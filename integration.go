@@ -2,6 +2,7 @@ package dogma
 
 import (
 	"context"
+	"log/slog"
 )
 
 // An IntegrationMessageHandler integrates a Dogma application with external and
@@ -45,6 +46,14 @@ type IntegrationConfigurer interface {
 	// route types.
 	Routes(...IntegrationRoute)
 
+	// ExternalSystem declares an external system that the handler
+	// communicates with.
+	//
+	// It MAY be called multiple times to declare several systems. The engine
+	// and related tooling MAY use this information to generate dependency maps
+	// and to aid incident response; it does not affect message routing.
+	ExternalSystem(ExternalSystem)
+
 	// Disable prevents the handler from receiving any messages.
 	//
 	// The engine MUST NOT call any methods other than Configure() on a disabled
@@ -55,16 +64,67 @@ type IntegrationConfigurer interface {
 	// without requiring the user to conditionally register the handler with the
 	// application.
 	Disable(...DisableOption)
+
+	// Warn records a non-fatal warning about the handler's configuration.
+	//
+	// The engine SHOULD report warnings prominently when it starts, such that
+	// the developer is able to address them. For example, an application may
+	// use warnings to announce that a route is deprecated.
+	Warn(format string, args ...any)
+}
+
+// ExternalSystem describes a system that an [IntegrationMessageHandler]
+// communicates with.
+type ExternalSystem struct {
+	// Name is a short human-readable name for the system, such as "Stripe"
+	// or "Corporate SMTP Relay". It MUST NOT be empty.
+	Name string
+
+	// Class is an application-defined string that describes the kind of
+	// endpoint used to communicate with the system, such as "http", "grpc" or
+	// "smtp".
+	Class string
+
+	// Critical indicates whether the application is unable to perform its
+	// primary function while the system is unavailable.
+	Critical bool
 }
 
 // IntegrationCommandScope performs engine operations within the context of a
 // call to the HandleCommand() method of an [IntegrationMessageHandler].
 type IntegrationCommandScope interface {
+	HandlerScope
+	Clock
+
 	// RecordEvent records the occurrence of an event.
 	RecordEvent(Event)
 
-	// Log records an informational message.
-	Log(format string, args ...any)
+	// RecordExternalCall records that the handler made a call to an external
+	// system while handling the command.
+	//
+	// name identifies the endpoint or operation that was called, such as
+	// "stripe.charges.create". attrs is an optional set of additional
+	// information about the call, such as the response status or the
+	// external system's request ID.
+	//
+	// The engine SHOULD persist or emit this information as telemetry, such
+	// that operators can audit which external systems were touched while
+	// handling each command. It MUST NOT treat the information as an event.
+	RecordExternalCall(name string, attrs ...slog.Attr)
+
+	// SetResult sets a value that's returned to the caller that executed the
+	// command, if the caller passed the [WithResult] option to
+	// ExecuteCommand().
+	//
+	// It's intended for small values that the caller needs immediately, such
+	// as the ID of a newly created entity. The engine MAY transmit the value
+	// between operating system processes, so it SHOULD be a value of a simple
+	// type, such as a string.
+	//
+	// Each call replaces the value set by any prior call on this scope. If the
+	// caller did not request a result, or the attempt to handle the command
+	// fails, the engine discards the value.
+	SetResult(v any)
 }
 
 // IntegrationRoute describes a message type that's routed to or from a
@@ -0,0 +1,56 @@
+package dogma
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+)
+
+// DeriveKey returns an identity key that's deterministically derived from a
+// namespace key and a name.
+//
+// ns is an RFC 4122 UUID, such as the application's identity key. name is an
+// arbitrary string, such as the name of a handler. The result is an RFC 9562
+// version 5 UUID, suitable for use as the k parameter to the Identity() method
+// of any configurer.
+//
+// The same ns and name always produce the same key. Therefore, changing either
+// value changes the derived key, which SHOULD NOT happen over the lifetime of
+// the application or handler. It panics if ns is not a valid UUID.
+func DeriveKey(ns, name string) string {
+	nsBytes, err := parseUUID(ns)
+	if err != nil {
+		panic(fmt.Sprintf("invalid namespace key: %s", err))
+	}
+
+	h := sha1.New()
+	h.Write(nsBytes[:])
+	h.Write([]byte(name))
+
+	var uuid [16]byte
+	copy(uuid[:], h.Sum(nil))
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // version 5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return formatUUID(uuid)
+}
+
+// parseUUID parses a UUID in its canonical hyphenated string form.
+func parseUUID(s string) (uuid [16]byte, err error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return uuid, fmt.Errorf("%q is not a UUID in canonical form", s)
+	}
+
+	hexDigits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(uuid[:], []byte(hexDigits)); err != nil {
+		return uuid, fmt.Errorf("%q is not a UUID in canonical form", s)
+	}
+
+	return uuid, nil
+}
+
+// formatUUID returns the canonical hyphenated string form of a UUID.
+func formatUUID(uuid [16]byte) string {
+	s := hex.EncodeToString(uuid[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
@@ -0,0 +1,57 @@
+package dogma_test
+
+import (
+	"testing"
+
+	. "github.com/dogmatiq/dogma"
+)
+
+func TestDeriveKey(t *testing.T) {
+	t.Run("it returns an RFC 9562 version 5 UUID", func(t *testing.T) {
+		// This test vector is the version 5 UUID for "www.example.com" in
+		// the DNS namespace, as defined in RFC 9562.
+		got := DeriveKey("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "www.example.com")
+		want := "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+
+		if got != want {
+			t.Fatalf("unexpected key: got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("it accepts upper-case namespace keys", func(t *testing.T) {
+		got := DeriveKey("6BA7B810-9DAD-11D1-80B4-00C04FD430C8", "www.example.com")
+		want := "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+
+		if got != want {
+			t.Fatalf("unexpected key: got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("it returns different keys for different names", func(t *testing.T) {
+		ns := "5195fe85-eb3f-4121-84b0-be72cbc5722f"
+
+		if DeriveKey(ns, "<name-1>") == DeriveKey(ns, "<name-2>") {
+			t.Fatal("expected different keys")
+		}
+	})
+
+	t.Run("it panics if the namespace is not a UUID", func(t *testing.T) {
+		cases := []string{
+			"",
+			"<namespace>",
+			"6ba7b8109dad11d180b400c04fd430c8",
+			"6ba7b810-9dad-11d1-80b4-00c04fd430cz",
+		}
+
+		for _, ns := range cases {
+			t.Run(ns, func(t *testing.T) {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Fatal("expected a panic")
+					}
+				}()
+				DeriveKey(ns, "<name>")
+			})
+		}
+	})
+}
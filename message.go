@@ -1,5 +1,10 @@
 package dogma
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // A Message is an application-defined unit of data that describes a [Command],
 // [Event], or [Timeout] within a message-based application.
 type Message interface {
@@ -39,24 +44,187 @@ type Timeout interface {
 
 // UnexpectedMessage is a panic value used by a message handler when it receives
 // a message of a type that it did not expect.
+//
+// A handler MAY instead panic with an [UnexpectedMessageError], such as by
+// calling [PanicUnexpectedMessage]. Therefore, the engine MUST NOT compare a
+// recovered panic value to UnexpectedMessage using the == operator. Instead, it
+// MUST treat any recovered value v that is an error for which
+// errors.Is(v, UnexpectedMessage) returns true as an unexpected message panic.
 var UnexpectedMessage unexpectedMessage
 
 type unexpectedMessage struct{}
 
+func (unexpectedMessage) Error() string {
+	return "unexpected message"
+}
+
+// PanicUnexpectedMessage panics with an [UnexpectedMessageError] that describes
+// m.
+//
+// A message handler MAY call this function in preference to panicking with
+// [UnexpectedMessage] directly, so that the engine is able to report the
+// message that caused the panic.
+func PanicUnexpectedMessage(m Message) {
+	panic(UnexpectedMessageError{m})
+}
+
+// UnexpectedMessageError is a panic value used by a message handler when it
+// receives a message of a type that it did not expect.
+//
+// It's equivalent to [UnexpectedMessage] when compared using [errors.Is].
+type UnexpectedMessageError struct {
+	// Message is the unexpected message.
+	Message Message
+}
+
+func (e UnexpectedMessageError) Error() string {
+	if e.Message == nil {
+		return UnexpectedMessage.Error()
+	}
+
+	return fmt.Sprintf(
+		"%s: %T (%s)",
+		UnexpectedMessage.Error(),
+		e.Message,
+		e.Message.MessageDescription(),
+	)
+}
+
+// Is returns true if target is [UnexpectedMessage].
+func (e UnexpectedMessageError) Is(target error) bool {
+	return target == UnexpectedMessage
+}
+
+// MessageValidationScope provides information about the context in which a
+// [Message] is being validated.
+//
+// It contains methods that are common to [CommandValidationScope],
+// [EventValidationScope] and [TimeoutValidationScope].
+type MessageValidationScope interface {
+	// Environment returns a coarse indicator of the environment in which the
+	// message is being validated.
+	//
+	// The message MAY use this value to accept values that are only valid in
+	// certain environments, such as placeholder data that's permitted in tests
+	// but MUST be rejected in production.
+	Environment() Environment
+
+	// Validator returns a human-readable name of the component that's
+	// performing the validation, such as the name of the engine or testing
+	// framework.
+	//
+	// The message SHOULD NOT use this value to alter its validation logic. It's
+	// intended for use in diagnostic messages only.
+	Validator() string
+}
+
 // CommandValidationScope provides information about the context in which a
 // [Command] is being validated.
 type CommandValidationScope interface {
+	MessageValidationScope
 	reservedCommandValidationScope()
 }
 
 // EventValidationScope provides information about the context in which an
 // [Event] is being validated.
 type EventValidationScope interface {
+	MessageValidationScope
 	reservedEventValidationScope()
 }
 
 // TimeoutValidationScope provides information about the context in which a
 // [Timeout] is being validated.
 type TimeoutValidationScope interface {
+	MessageValidationScope
 	reservedTimeoutValidationScope()
 }
+
+// Environment is a coarse indicator of the environment in which the engine is
+// operating.
+type Environment int
+
+const (
+	// ProductionEnvironment indicates that the engine is operating in a
+	// production environment, or in any environment that is not known to be a
+	// test environment.
+	//
+	// It's the zero-value of [Environment] so that validation logic defaults to
+	// its strictest behavior.
+	ProductionEnvironment Environment = iota
+
+	// TestEnvironment indicates that the engine is operating within an
+	// automated test, such as those run by [github.com/dogmatiq/testkit].
+	TestEnvironment
+)
+
+// NoPayloadCommand is an embeddable type for [Command] implementations that
+// carry no data beyond their type.
+//
+// T MUST be the non-pointer type that embeds NoPayloadCommand, as its methods
+// use non-pointer receivers. For example:
+//
+//	type StopReactor struct {
+//		dogma.NoPayloadCommand[StopReactor]
+//	}
+type NoPayloadCommand[T any] struct{}
+
+// MessageDescription returns the name of T.
+//
+// The type name is a fallback. T MAY declare its own MessageDescription()
+// method to return a more meaningful description.
+func (NoPayloadCommand[T]) MessageDescription() string {
+	return reflect.TypeFor[T]().Name()
+}
+
+// Validate returns nil.
+func (NoPayloadCommand[T]) Validate(CommandValidationScope) error {
+	return nil
+}
+
+// NoPayloadEvent is an embeddable type for [Event] implementations that carry
+// no data beyond their type.
+//
+// T MUST be the non-pointer type that embeds NoPayloadEvent, as its methods
+// use non-pointer receivers. For example:
+//
+//	type ReactorStopped struct {
+//		dogma.NoPayloadEvent[ReactorStopped]
+//	}
+type NoPayloadEvent[T any] struct{}
+
+// MessageDescription returns the name of T.
+//
+// The type name is a fallback. T MAY declare its own MessageDescription()
+// method to return a more meaningful description.
+func (NoPayloadEvent[T]) MessageDescription() string {
+	return reflect.TypeFor[T]().Name()
+}
+
+// Validate returns nil.
+func (NoPayloadEvent[T]) Validate(EventValidationScope) error {
+	return nil
+}
+
+// NoPayloadTimeout is an embeddable type for [Timeout] implementations that
+// carry no data beyond their type.
+//
+// T MUST be the non-pointer type that embeds NoPayloadTimeout, as its methods
+// use non-pointer receivers. For example:
+//
+//	type ReactorCooldownElapsed struct {
+//		dogma.NoPayloadTimeout[ReactorCooldownElapsed]
+//	}
+type NoPayloadTimeout[T any] struct{}
+
+// MessageDescription returns the name of T.
+//
+// The type name is a fallback. T MAY declare its own MessageDescription()
+// method to return a more meaningful description.
+func (NoPayloadTimeout[T]) MessageDescription() string {
+	return reflect.TypeFor[T]().Name()
+}
+
+// Validate returns nil.
+func (NoPayloadTimeout[T]) Validate(TimeoutValidationScope) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package dogma_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/dogmatiq/dogma"
+)
+
+type (
+	noPayloadCommand struct {
+		NoPayloadCommand[noPayloadCommand]
+	}
+	noPayloadEvent   struct{ NoPayloadEvent[noPayloadEvent] }
+	noPayloadTimeout struct {
+		NoPayloadTimeout[noPayloadTimeout]
+	}
+)
+
+func TestNoPayloadCommand(t *testing.T) {
+	var m Command = noPayloadCommand{}
+
+	if d := m.MessageDescription(); d != "noPayloadCommand" {
+		t.Fatalf("unexpected description: %q", d)
+	}
+
+	if err := m.Validate(nil); err != nil {
+		t.Fatal("unexpected error returned")
+	}
+}
+
+func TestNoPayloadEvent(t *testing.T) {
+	var m Event = noPayloadEvent{}
+
+	if d := m.MessageDescription(); d != "noPayloadEvent" {
+		t.Fatalf("unexpected description: %q", d)
+	}
+
+	if err := m.Validate(nil); err != nil {
+		t.Fatal("unexpected error returned")
+	}
+}
+
+func TestNoPayloadTimeout(t *testing.T) {
+	var m Timeout = noPayloadTimeout{}
+
+	if d := m.MessageDescription(); d != "noPayloadTimeout" {
+		t.Fatalf("unexpected description: %q", d)
+	}
+
+	if err := m.Validate(nil); err != nil {
+		t.Fatal("unexpected error returned")
+	}
+}
+
+func TestPanicUnexpectedMessage(t *testing.T) {
+	m := noPayloadCommand{}
+
+	defer func() {
+		r := recover()
+
+		err, ok := r.(UnexpectedMessageError)
+		if !ok {
+			t.Fatalf("unexpected panic value: %#v", r)
+		}
+
+		if err.Message != m {
+			t.Fatal("unexpected message")
+		}
+
+		if !errors.Is(err, UnexpectedMessage) {
+			t.Fatal("expected error to match UnexpectedMessage")
+		}
+
+		want := "unexpected message: dogma_test.noPayloadCommand (noPayloadCommand)"
+		if got := err.Error(); got != want {
+			t.Fatalf("unexpected error message: got %q, want %q", got, want)
+		}
+	}()
+
+	PanicUnexpectedMessage(m)
+}
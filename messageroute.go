@@ -3,6 +3,7 @@ package dogma
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // HandlesCommand routes command messages to an [AggregateMessageHandler] or
@@ -10,8 +11,12 @@ import (
 // of [AggregateConfigurer] or [IntegrationConfigurer].
 //
 // An application MUST NOT route a single command type to more than one handler.
-func HandlesCommand[T Command](...HandlesCommandOption) HandlesCommandRoute {
-	return HandlesCommandRoute{typeOf[Command, T]()}
+func HandlesCommand[T Command](opts ...HandlesCommandOption) HandlesCommandRoute {
+	r := HandlesCommandRoute{Type: typeOf[Command, T]()}
+	for _, opt := range opts {
+		opt.applyToHandlesCommand(&r)
+	}
+	return r
 }
 
 // RecordsEvent routes event messages recorded by an [AggregateMessageHandler]
@@ -19,22 +24,115 @@ func HandlesCommand[T Command](...HandlesCommandOption) HandlesCommandRoute {
 // method of [AggregateConfigurer] or [IntegrationConfigurer].
 //
 // An application MUST NOT route a single event type from more than one handler.
-func RecordsEvent[T Event](...RecordsEventOption) RecordsEventRoute {
-	return RecordsEventRoute{typeOf[Event, T]()}
+func RecordsEvent[T Event](opts ...RecordsEventOption) RecordsEventRoute {
+	r := RecordsEventRoute{Type: typeOf[Event, T]()}
+	for _, opt := range opts {
+		opt.applyToRecordsEvent(&r)
+	}
+	return r
 }
 
 // HandlesEvent routes event messages to a [ProcessMessageHandler] or
 // [ProjectionMessageHandler]. It is used as an argument to the Routes() method
 // of [ProcessConfigurer] or [ProjectionConfigurer].
-func HandlesEvent[T Event](...HandlesEventOption) HandlesEventRoute {
-	return HandlesEventRoute{typeOf[Event, T]()}
+func HandlesEvent[T Event](opts ...HandlesEventOption) HandlesEventRoute {
+	r := HandlesEventRoute{Type: typeOf[Event, T]()}
+	for _, opt := range opts {
+		opt.applyToHandlesEvent(&r)
+	}
+	return r
+}
+
+// HandlesEventFrom routes event messages recorded by a different application to
+// a [ProcessMessageHandler] or [ProjectionMessageHandler]. It is used as an
+// argument to the Routes() method of [ProcessConfigurer] or
+// [ProjectionConfigurer].
+//
+// It's equivalent to [HandlesEvent], except that the events are consumed from
+// the application with the identity key k, instead of from the application
+// that contains the handler. k MUST be an RFC 4122 UUID in its canonical
+// hyphenated string form.
+//
+// The engine MUST either deliver such events to the handler, or fail at
+// startup if it's unable to consume events from the other application.
+func HandlesEventFrom[T Event](k string, opts ...HandlesEventOption) HandlesEventRoute {
+	if k == "" {
+		panic("application key must not be empty")
+	}
+
+	if _, err := parseUUID(k); err != nil {
+		panic(fmt.Sprintf("invalid application key: %s", err))
+	}
+
+	r := HandlesEvent[T](opts...)
+	r.SourceApplicationKey = k
+
+	return r
+}
+
+// HandlesAnyEvent routes all event messages to a [ProjectionMessageHandler].
+// It is used as an argument to the Routes() method of [ProjectionConfigurer].
+//
+// The handler receives events of every type that's recorded by any handler
+// within the application, as per their [RecordsEventRoute] routes, including
+// event types that are added to the application in the future.
+//
+// It's intended for projections that are not concerned with specific event
+// types, such as audit logs.
+//
+// A projection MAY use [HandlesEvent] alongside this route. The options of a
+// [HandlesEventRoute] apply to events of its type; the options of this route
+// apply only to events of those types that have no such route.
+func HandlesAnyEvent(opts ...HandlesAnyEventOption) HandlesAnyEventRoute {
+	var r HandlesAnyEventRoute
+	for _, opt := range opts {
+		opt.applyToHandlesAnyEvent(&r)
+	}
+	return r
+}
+
+// HandlesEventWhere routes event messages to a [ProcessMessageHandler] or
+// [ProjectionMessageHandler] if they satisfy a predicate function. It is used
+// as an argument to the Routes() method of [ProcessConfigurer] or
+// [ProjectionConfigurer].
+//
+// It's equivalent to [HandlesEvent], except that the handler only requires
+// those events of type T for which pred returns true.
+//
+// pred MUST be deterministic and free of side-effects. The engine MAY use pred
+// to avoid delivering events to the handler that it does not require. The
+// engine is not required to call pred; the handler MUST tolerate receiving
+// events for which pred returns false.
+//
+// pred only applies to events of type T. If the route accepts prior versions of
+// the event, as per [WithPriorVersion], the handler always requires events of
+// those types.
+func HandlesEventWhere[T Event](
+	pred func(T) bool,
+	opts ...HandlesEventOption,
+) HandlesEventRoute {
+	if pred == nil {
+		panic("predicate must not be nil")
+	}
+
+	r := HandlesEvent[T](opts...)
+	r.Predicate = func(e Event) bool {
+		t, ok := e.(T)
+		return !ok || pred(t)
+	}
+
+	return r
 }
 
 // ExecutesCommand routes command messages produced by a
 // [ProcessMessageHandler]. It is used as an argument to the Routes() method of
 // [ProcessConfigurer].
-func ExecutesCommand[T Command](...ExecutesCommandOption) ExecutesCommandRoute {
-	return ExecutesCommandRoute{typeOf[Command, T]()}
+func ExecutesCommand[T Command](opts ...ExecutesCommandOption) ExecutesCommandRoute {
+	r := ExecutesCommandRoute{Type: typeOf[Command, T]()}
+	for _, opt := range opts {
+		opt.applyToExecutesCommand(&r)
+	}
+	return r
 }
 
 // SchedulesTimeout routes timeout messages scheduled by
@@ -42,14 +140,24 @@ func ExecutesCommand[T Command](...ExecutesCommandOption) ExecutesCommandRoute {
 // [ProcessConfigurer].
 //
 // An application MAY use a single timeout type with more than one process.
-func SchedulesTimeout[T Timeout](...SchedulesTimeoutOption) SchedulesTimeoutRoute {
-	return SchedulesTimeoutRoute{typeOf[Timeout, T]()}
+func SchedulesTimeout[T Timeout](opts ...SchedulesTimeoutOption) SchedulesTimeoutRoute {
+	r := SchedulesTimeoutRoute{Type: typeOf[Timeout, T]()}
+	for _, opt := range opts {
+		opt.applyToSchedulesTimeout(&r)
+	}
+	return r
 }
 
 type (
 	// MessageRoute is an interface for types that describe a relationship between a
 	// message handler and a specific message type.
-	MessageRoute = interface{ isMessageRoute() }
+	MessageRoute = interface {
+		// ApplyMessageRoute calls the method on b that corresponds to the
+		// route's type.
+		ApplyMessageRoute(b MessageRoutesBuilder)
+
+		isMessageRoute()
+	}
 
 	// Route is an alias for [MessageRoute]
 	//
@@ -58,47 +166,811 @@ type (
 
 	// HandlesCommandRoute describes a route for a handler that handles a
 	// [Command] of a specific type.
-	HandlesCommandRoute struct{ Type reflect.Type }
+	HandlesCommandRoute struct {
+		Type reflect.Type
+
+		// SamplingRate is the proportion of messages for which the engine
+		// should record observability data, as per [WithSamplingRate]. A value
+		// of zero means the engine's default sampling rate is used.
+		SamplingRate float64
+
+		// Priority is the relative priority of messages of this type, as per
+		// [WithRoutePriority].
+		Priority int
+
+		// MaxConcurrency is the maximum number of messages of this type that
+		// the engine may handle concurrently, as per [WithMaxConcurrency]. A
+		// value of zero means there is no limit.
+		MaxConcurrency int
+
+		// DeprecationReason is the reason that the route is deprecated, as per
+		// [WithDeprecated]. An empty string means the route is not deprecated.
+		DeprecationReason string
+
+		// IsExposedExternally indicates whether commands of this type may be
+		// submitted by systems outside the application, as per
+		// [ExposeExternally].
+		IsExposedExternally bool
+
+		// AuthScopes is the set of authorization scopes that an external
+		// caller requires to submit commands of this type, as per
+		// [ExposeExternally].
+		AuthScopes []string
+
+		// IsIdempotent indicates whether handling a command of this type more
+		// than once has the same effect as handling it once, as per
+		// [Idempotent].
+		IsIdempotent bool
+
+		// HandlingDeadline is the maximum amount of time that the engine
+		// allows an integration handler to handle a single command of this
+		// type, as per [WithHandlingDeadline]. A value of zero means the
+		// engine's default deadline is used.
+		HandlingDeadline time.Duration
+
+		// Labels is a set of key/value pairs that describe the route, as per
+		// [WithRouteLabel].
+		Labels map[string]string
+
+		// RetryPolicy describes how the engine retries failed attempts to
+		// handle messages of this type, as per [WithRetryPolicy].
+		RetryPolicy RetryPolicy
+
+		// IsAtMostOnce indicates whether messages of this type are delivered to
+		// the handler at most once, as per [AtMostOnce].
+		IsAtMostOnce bool
+	}
 
 	// ExecutesCommandRoute describes a route for a handler that executes a
 	// [Command] of a specific type.
-	ExecutesCommandRoute struct{ Type reflect.Type }
+	ExecutesCommandRoute struct {
+		Type reflect.Type
+
+		// MinimumDelay is the minimum amount of time that the engine must wait
+		// before dispatching a command of this type, as per
+		// [WithMinimumDelay].
+		MinimumDelay time.Duration
+
+		// DeprecationReason is the reason that the route is deprecated, as per
+		// [WithDeprecated]. An empty string means the route is not deprecated.
+		DeprecationReason string
+
+		// Labels is a set of key/value pairs that describe the route, as per
+		// [WithRouteLabel].
+		Labels map[string]string
+	}
 
 	// HandlesEventRoute describes a route for a handler that handles an
 	// [Event] of a specific type.
-	HandlesEventRoute struct{ Type reflect.Type }
+	HandlesEventRoute struct {
+		Type reflect.Type
+
+		// PriorVersions is a list of event types that represent prior versions
+		// of the logical event described by Type, as per [WithPriorVersion].
+		PriorVersions []reflect.Type
+
+		// SamplingRate is the proportion of messages for which the engine
+		// should record observability data, as per [WithSamplingRate]. A value
+		// of zero means the engine's default sampling rate is used.
+		SamplingRate float64
+
+		// Predicate reports whether the handler requires a specific event, as
+		// per [HandlesEventWhere]. If it's nil, the handler requires all events
+		// of this type. The engine MAY call it with an event of any of the
+		// route's types, including those in PriorVersions; it returns true for
+		// any event that's not of the type given by Type.
+		Predicate func(Event) bool
+
+		// Priority is the relative priority of messages of this type, as per
+		// [WithRoutePriority].
+		Priority int
+
+		// MaxConcurrency is the maximum number of messages of this type that
+		// the engine may handle concurrently, as per [WithMaxConcurrency]. A
+		// value of zero means there is no limit.
+		MaxConcurrency int
+
+		// DeprecationReason is the reason that the route is deprecated, as per
+		// [WithDeprecated]. An empty string means the route is not deprecated.
+		DeprecationReason string
+
+		// Labels is a set of key/value pairs that describe the route, as per
+		// [WithRouteLabel].
+		Labels map[string]string
+
+		// StartsInstance indicates whether events of this type may begin a new
+		// process instance, as per [StartsInstance].
+		StartsInstance bool
+
+		// SourceApplicationKey is the identity key of the application that
+		// records events of this type, as per [HandlesEventFrom]. An empty
+		// string means the events are recorded by the handler's own
+		// application.
+		SourceApplicationKey string
+
+		// RetryPolicy describes how the engine retries failed attempts to
+		// handle messages of this type, as per [WithRetryPolicy].
+		RetryPolicy RetryPolicy
+
+		// IsEphemeral indicates whether events of this type are delivered on a
+		// best-effort basis without being persisted, as per
+		// [WithEphemeralDelivery].
+		IsEphemeral bool
+
+		// IsAtMostOnce indicates whether messages of this type are delivered to
+		// the handler at most once, as per [AtMostOnce].
+		IsAtMostOnce bool
+	}
+
+	// HandlesAnyEventRoute describes a route for a handler that handles
+	// [Event] messages of any type.
+	HandlesAnyEventRoute struct {
+		// DeprecationReason is the reason that the route is deprecated, as per
+		// [WithDeprecated]. An empty string means the route is not deprecated.
+		DeprecationReason string
+
+		// Labels is a set of key/value pairs that describe the route, as per
+		// [WithRouteLabel].
+		Labels map[string]string
+	}
 
 	// RecordsEventRoute describes a route for a handler that records an
 	// [Event] of a specific type.
-	RecordsEventRoute struct{ Type reflect.Type }
+	RecordsEventRoute struct {
+		Type reflect.Type
+
+		// DeprecationReason is the reason that the route is deprecated, as per
+		// [WithDeprecated]. An empty string means the route is not deprecated.
+		DeprecationReason string
+
+		// Labels is a set of key/value pairs that describe the route, as per
+		// [WithRouteLabel].
+		Labels map[string]string
+
+		// RetentionPolicy describes how long the engine must retain events of
+		// this type, as per [WithRetention]. A nil value is equivalent to
+		// [IndefiniteEventRetentionPolicy].
+		RetentionPolicy EventRetentionPolicy
+
+		// IsEphemeral indicates whether events of this type are delivered on a
+		// best-effort basis without being persisted, as per
+		// [WithEphemeralDelivery].
+		IsEphemeral bool
+	}
 
 	// SchedulesTimeoutRoute describes a route for a handler that schedules a
 	// [Timeout] of a specific type.
-	SchedulesTimeoutRoute struct{ Type reflect.Type }
+	SchedulesTimeoutRoute struct {
+		Type reflect.Type
+
+		// DeprecationReason is the reason that the route is deprecated, as per
+		// [WithDeprecated]. An empty string means the route is not deprecated.
+		DeprecationReason string
+
+		// Labels is a set of key/value pairs that describe the route, as per
+		// [WithRouteLabel].
+		Labels map[string]string
+
+		// MaxScheduleHorizon is the furthest into the future that the handler
+		// schedules timeouts of this type, as per [WithMaxScheduleHorizon]. A
+		// value of zero means there is no limit.
+		MaxScheduleHorizon time.Duration
+	}
 )
 
+// MessageRoutesBuilder is an interface for building a representation of a
+// handler's message routes.
+//
+// It allows engines and tooling to inspect [MessageRoute] values without
+// switching over each of the concrete route types. See
+// MessageRoute.ApplyMessageRoute().
+type MessageRoutesBuilder interface {
+	HandlesCommand(HandlesCommandRoute)
+	ExecutesCommand(ExecutesCommandRoute)
+	HandlesEvent(HandlesEventRoute)
+	HandlesAnyEvent(HandlesAnyEventRoute)
+	RecordsEvent(RecordsEventRoute)
+	SchedulesTimeout(SchedulesTimeoutRoute)
+}
+
+// ApplyMessageRoute calls b.HandlesCommand(r).
+func (r HandlesCommandRoute) ApplyMessageRoute(b MessageRoutesBuilder) {
+	b.HandlesCommand(r)
+}
+
+// ApplyMessageRoute calls b.ExecutesCommand(r).
+func (r ExecutesCommandRoute) ApplyMessageRoute(b MessageRoutesBuilder) {
+	b.ExecutesCommand(r)
+}
+
+// ApplyMessageRoute calls b.HandlesEvent(r).
+func (r HandlesEventRoute) ApplyMessageRoute(b MessageRoutesBuilder) {
+	b.HandlesEvent(r)
+}
+
+// ApplyMessageRoute calls b.HandlesAnyEvent(r).
+func (r HandlesAnyEventRoute) ApplyMessageRoute(b MessageRoutesBuilder) {
+	b.HandlesAnyEvent(r)
+}
+
+// ApplyMessageRoute calls b.RecordsEvent(r).
+func (r RecordsEventRoute) ApplyMessageRoute(b MessageRoutesBuilder) {
+	b.RecordsEvent(r)
+}
+
+// ApplyMessageRoute calls b.SchedulesTimeout(r).
+func (r SchedulesTimeoutRoute) ApplyMessageRoute(b MessageRoutesBuilder) {
+	b.SchedulesTimeout(r)
+}
+
 type (
 	// HandlesCommandOption is an option that affects the behavior of the route
 	// returned by [HandlesCommand].
-	HandlesCommandOption struct{}
+	HandlesCommandOption interface {
+		applyToHandlesCommand(*HandlesCommandRoute)
+	}
 
 	// ExecutesCommandOption is an option that affects the behavior of the route
 	// returned by [ExecutesCommand].
-	ExecutesCommandOption struct{}
+	ExecutesCommandOption interface {
+		applyToExecutesCommand(*ExecutesCommandRoute)
+	}
 
 	// HandlesEventOption is an option that affects the behavior of the route
 	// returned by [HandlesEvent].
-	HandlesEventOption struct{}
+	HandlesEventOption interface {
+		applyToHandlesEvent(*HandlesEventRoute)
+	}
+
+	// HandlesAnyEventOption is an option that affects the behavior of the
+	// route returned by [HandlesAnyEvent].
+	HandlesAnyEventOption interface {
+		applyToHandlesAnyEvent(*HandlesAnyEventRoute)
+	}
 
 	// RecordsEventOption is an option that affects the behavior of the route
 	// returned by [RecordsEvent].
-	RecordsEventOption struct{}
+	RecordsEventOption interface {
+		applyToRecordsEvent(*RecordsEventRoute)
+	}
 
 	// SchedulesTimeoutOption is an option that affects the behavior of the
 	// route returned by [SchedulesTimeout].
-	SchedulesTimeoutOption struct{}
+	SchedulesTimeoutOption interface {
+		applyToSchedulesTimeout(*SchedulesTimeoutRoute)
+	}
 )
 
+// WithPriorVersion returns an option that additionally routes events of type T
+// to the handler, as a prior version of the route's event type.
+//
+// It allows a handler to consume several versions of a single logical event
+// within the same route. The handler distinguishes between the versions using
+// the Go type of the event passed to its HandleEvent() method.
+//
+// The option MAY be specified multiple times to route several prior versions.
+// The event types SHOULD be given in order from oldest to newest.
+func WithPriorVersion[T Event]() PriorVersionOption {
+	return PriorVersionOption{typeOf[Event, T]()}
+}
+
+// PriorVersionOption is a [HandlesEventOption] that routes a prior version of
+// an event type to the handler. See [WithPriorVersion].
+type PriorVersionOption struct{ t reflect.Type }
+
+func (o PriorVersionOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.PriorVersions = append(r.PriorVersions, o.t)
+}
+
+// WithSamplingRate returns an option that controls the proportion of messages
+// for which the engine records observability data, such as traces and logs.
+//
+// p is the proportion of messages to sample, between 0 (exclusive) and 1
+// (inclusive). For example, 0.01 samples 1% of messages.
+//
+// The engine SHOULD apply the sampling rate to observability data that it
+// produces about the handling of each message. It MUST NOT apply the sampling
+// rate to the handling of the message itself.
+func WithSamplingRate(p float64) SamplingRateOption {
+	if p <= 0 || p > 1 {
+		panic("sampling rate must be in the range (0, 1]")
+	}
+	return SamplingRateOption{p}
+}
+
+// SamplingRateOption is an option that controls the proportion of messages for
+// which the engine records observability data. See [WithSamplingRate].
+type SamplingRateOption struct{ p float64 }
+
+func (o SamplingRateOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.SamplingRate = o.p
+}
+
+func (o SamplingRateOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.SamplingRate = o.p
+}
+
+// WithRoutePriority returns an option that hints at the relative priority of
+// messages of a specific type.
+//
+// Messages on routes with a higher priority SHOULD be dispatched ahead of those
+// with a lower priority when the engine has a backlog of messages to process.
+// The default priority is zero. Negative priorities are permitted.
+//
+// The priority is a hint only. The engine MAY ignore it, and it does not
+// affect the order in which the engine delivers messages of the same type.
+func WithRoutePriority(n int) RoutePriorityOption {
+	return RoutePriorityOption{n}
+}
+
+// RoutePriorityOption is an option that hints at the relative priority of
+// messages of a specific type. See [WithRoutePriority].
+type RoutePriorityOption struct{ n int }
+
+func (o RoutePriorityOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.Priority = o.n
+}
+
+func (o RoutePriorityOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.Priority = o.n
+}
+
+// WithMinimumDelay returns an option that delays the dispatch of commands of a
+// specific type.
+//
+// d is the minimum amount of time that the engine MUST wait after the command
+// is executed by the handler before dispatching it to the handler that handles
+// it. It MUST be positive.
+//
+// This allows a process to model a "cooling off" period without scheduling a
+// [Timeout] message.
+func WithMinimumDelay(d time.Duration) MinimumDelayOption {
+	if d <= 0 {
+		panic("minimum delay must be positive")
+	}
+	return MinimumDelayOption{d}
+}
+
+// MinimumDelayOption is an option that delays the dispatch of commands of a
+// specific type. See [WithMinimumDelay].
+type MinimumDelayOption struct{ d time.Duration }
+
+func (o MinimumDelayOption) applyToExecutesCommand(r *ExecutesCommandRoute) {
+	r.MinimumDelay = o.d
+}
+
+// WithMaxConcurrency returns an option that limits the number of messages of a
+// specific type that the engine handles concurrently.
+//
+// n is the maximum number of concurrent calls to the handler's message-handling
+// method with messages of this type, across all goroutines and operating system
+// processes. It MUST be positive.
+//
+// This is useful when handling a specific message type involves a resource with
+// limited capacity, such as a rate-limited API.
+func WithMaxConcurrency(n int) MaxConcurrencyOption {
+	if n <= 0 {
+		panic("maximum concurrency must be positive")
+	}
+	return MaxConcurrencyOption{n}
+}
+
+// MaxConcurrencyOption is an option that limits the number of messages of a
+// specific type that the engine handles concurrently. See
+// [WithMaxConcurrency].
+type MaxConcurrencyOption struct{ n int }
+
+func (o MaxConcurrencyOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.MaxConcurrency = o.n
+}
+
+func (o MaxConcurrencyOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.MaxConcurrency = o.n
+}
+
+// WithDeprecated returns an option that marks a route as deprecated.
+//
+// reason is a human-readable explanation of why the route is deprecated, and
+// what replaces it, if anything. It MUST NOT be empty.
+//
+// A deprecated route behaves exactly as it would otherwise. The engine and
+// related tooling MAY report the deprecation, such as by logging a warning at
+// startup or omitting the route from generated documentation.
+func WithDeprecated(reason string) DeprecatedOption {
+	if reason == "" {
+		panic("deprecation reason must not be empty")
+	}
+	return DeprecatedOption{reason}
+}
+
+// DeprecatedOption is an option that marks a route as deprecated. See
+// [WithDeprecated].
+type DeprecatedOption struct{ reason string }
+
+func (o DeprecatedOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.DeprecationReason = o.reason
+}
+
+func (o DeprecatedOption) applyToExecutesCommand(r *ExecutesCommandRoute) {
+	r.DeprecationReason = o.reason
+}
+
+func (o DeprecatedOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.DeprecationReason = o.reason
+}
+
+func (o DeprecatedOption) applyToHandlesAnyEvent(r *HandlesAnyEventRoute) {
+	r.DeprecationReason = o.reason
+}
+
+func (o DeprecatedOption) applyToRecordsEvent(r *RecordsEventRoute) {
+	r.DeprecationReason = o.reason
+}
+
+func (o DeprecatedOption) applyToSchedulesTimeout(r *SchedulesTimeoutRoute) {
+	r.DeprecationReason = o.reason
+}
+
+// ExposeExternally returns an option that declares that commands of a specific
+// type may be submitted by systems outside the application, such as via an API
+// gateway.
+//
+// scopes is the set of authorization scopes that an external caller requires
+// in order to submit the command. The scopes are application-defined. If no
+// scopes are given, any caller that's permitted to access the gateway may
+// submit the command.
+//
+// This option does not affect how the engine handles the command. It's
+// intended for tooling that generates API gateways from the application's
+// configuration. Such tooling SHOULD NOT expose commands that are not
+// explicitly exposed using this option.
+func ExposeExternally(scopes ...string) ExternalExposureOption {
+	for _, s := range scopes {
+		if s == "" {
+			panic("authorization scope must not be empty")
+		}
+	}
+	return ExternalExposureOption{scopes}
+}
+
+// ExternalExposureOption is an option that declares that commands of a
+// specific type may be submitted by systems outside the application. See
+// [ExposeExternally].
+type ExternalExposureOption struct{ scopes []string }
+
+func (o ExternalExposureOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.IsExposedExternally = true
+	r.AuthScopes = append(r.AuthScopes, o.scopes...)
+}
+
+// WithRouteLabel returns an option that attaches a key/value pair to a route.
+//
+// k is the label's key. It MUST NOT be empty. If the same key is specified
+// multiple times, the last value takes precedence. v is the label's value.
+//
+// Labels do not affect how the engine handles messages. The engine SHOULD
+// propagate route labels to the observability data that it produces, such as
+// metrics and traces. Labels are intended to describe operational concerns,
+// such as the team responsible for a route, or its service-level tier.
+func WithRouteLabel(k, v string) RouteLabelOption {
+	if k == "" {
+		panic("label key must not be empty")
+	}
+	return RouteLabelOption{k, v}
+}
+
+// RouteLabelOption is an option that attaches a key/value pair to a route. See
+// [WithRouteLabel].
+type RouteLabelOption struct{ k, v string }
+
+func (o RouteLabelOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o RouteLabelOption) applyToExecutesCommand(r *ExecutesCommandRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o RouteLabelOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o RouteLabelOption) applyToHandlesAnyEvent(r *HandlesAnyEventRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o RouteLabelOption) applyToRecordsEvent(r *RecordsEventRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+func (o RouteLabelOption) applyToSchedulesTimeout(r *SchedulesTimeoutRoute) {
+	r.Labels = withLabel(r.Labels, o.k, o.v)
+}
+
+// withLabel returns labels with the label k set to v, allocating the map if
+// necessary.
+func withLabel(labels map[string]string, k, v string) map[string]string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[k] = v
+	return labels
+}
+
+// StartsInstance returns an option that declares that events of a specific type
+// may begin a new process instance. It's only meaningful when used with a
+// [ProcessMessageHandler].
+//
+// If any of a process's [HandlesEventRoute] routes use this option, the engine
+// MUST NOT begin a new instance in response to an event on a route that does
+// not. Such events are only delivered to existing instances; if the instance
+// returned by RouteEventToInstance() has not begun, or has ended, the engine
+// MUST ignore the event.
+//
+// If none of a process's routes use this option, any event may begin a new
+// instance.
+//
+// This prevents "ghost" instances from being created when an event arrives
+// after the instance it relates to has already ended.
+func StartsInstance() StartsInstanceOption {
+	return StartsInstanceOption{}
+}
+
+// StartsInstanceOption is an option that declares that events of a specific
+// type may begin a new process instance. See [StartsInstance].
+type StartsInstanceOption struct{}
+
+func (StartsInstanceOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.StartsInstance = true
+}
+
+// Idempotent returns an option that declares that handling a command of a
+// specific type more than once has the same effect as handling it exactly once.
+//
+// The engine MAY use this information to relax the bookkeeping that it
+// performs to prevent duplicate handling of commands of this type.
+//
+// The application is responsible for ensuring that the claim is true. For
+// example, a command that sets a value is typically idempotent, whereas a
+// command that increments a value is not.
+func Idempotent() IdempotentOption {
+	return IdempotentOption{}
+}
+
+// IdempotentOption is an option that declares that handling a command of a
+// specific type more than once has the same effect as handling it exactly
+// once. See [Idempotent].
+type IdempotentOption struct{}
+
+func (IdempotentOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.IsIdempotent = true
+}
+
+// WithMaxScheduleHorizon returns an option that declares the furthest into the
+// future that a process schedules timeouts of a specific type.
+//
+// d is the maximum duration between the time at which a timeout is scheduled
+// and the time at which it's scheduled to occur. It MUST be positive.
+//
+// The engine MAY use this information to partition its timeout storage. The
+// engine SHOULD reject attempts to schedule a timeout beyond the horizon, such
+// as by panicking from within the call to ScheduleTimeout(). This helps detect
+// programming errors such as unit conversion bugs.
+func WithMaxScheduleHorizon(d time.Duration) MaxScheduleHorizonOption {
+	if d <= 0 {
+		panic("maximum schedule horizon must be positive")
+	}
+	return MaxScheduleHorizonOption{d}
+}
+
+// MaxScheduleHorizonOption is an option that declares the furthest into the
+// future that a process schedules timeouts of a specific type. See
+// [WithMaxScheduleHorizon].
+type MaxScheduleHorizonOption struct{ d time.Duration }
+
+func (o MaxScheduleHorizonOption) applyToSchedulesTimeout(r *SchedulesTimeoutRoute) {
+	r.MaxScheduleHorizon = o.d
+}
+
+// WithHandlingDeadline returns an option that limits the amount of time that
+// the engine allows an [IntegrationMessageHandler] to handle a single command
+// of a specific type.
+//
+// d is the maximum duration of each call to the handler's HandleCommand()
+// method. It MUST be positive.
+//
+// The engine MUST cancel the context passed to HandleCommand() once d has
+// elapsed. The engine MAY impose a shorter deadline, such as when it's shutting
+// down.
+//
+// The option is only valid on routes that belong to an integration handler. The
+// engine MUST report a configuration error if it's used on a route that belongs
+// to an [AggregateMessageHandler]. See ADR-22 for the rationale.
+func WithHandlingDeadline(d time.Duration) HandlingDeadlineOption {
+	if d <= 0 {
+		panic("handling deadline must be positive")
+	}
+	return HandlingDeadlineOption{d}
+}
+
+// HandlingDeadlineOption is an option that limits the amount of time that the
+// engine allows for handling a single command of a specific type. See
+// [WithHandlingDeadline].
+type HandlingDeadlineOption struct{ d time.Duration }
+
+func (o HandlingDeadlineOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.HandlingDeadline = o.d
+}
+
+// WithRetention returns an option that declares how long the engine must retain
+// events of a specific type.
+//
+// By default, events are retained indefinitely. It panics if p is nil, or is an
+// [ArchiveEventRetentionPolicy] with a non-positive After duration.
+func WithRetention(p EventRetentionPolicy) RetentionOption {
+	if p == nil {
+		panic("retention policy must not be nil")
+	}
+	if p, ok := p.(ArchiveEventRetentionPolicy); ok && p.After <= 0 {
+		panic("archive retention period must be positive")
+	}
+	return RetentionOption{p}
+}
+
+// RetentionOption is an option that declares how long the engine must retain
+// events of a specific type. See [WithRetention].
+type RetentionOption struct{ p EventRetentionPolicy }
+
+func (o RetentionOption) applyToRecordsEvent(r *RecordsEventRoute) {
+	r.RetentionPolicy = o.p
+}
+
+type (
+	// An EventRetentionPolicy describes how long the engine must retain events
+	// of a specific type.
+	//
+	// Retention policies are intended to support data lifecycle requirements,
+	// such as those imposed by privacy regulations. An engine that's unable to
+	// honor a policy SHOULD fail at startup.
+	EventRetentionPolicy interface{ isEventRetentionPolicy() }
+
+	// IndefiniteEventRetentionPolicy is the default [EventRetentionPolicy]. The
+	// engine MUST retain events indefinitely.
+	IndefiniteEventRetentionPolicy struct{}
+
+	// ArchiveEventRetentionPolicy is an [EventRetentionPolicy] that allows the
+	// engine to move events to archival storage once they reach a certain age.
+	//
+	// The engine MAY omit archived events when supplying historical events to
+	// process and projection handlers, and to an [EventReader]. It MUST NOT
+	// omit archived events when loading an aggregate instance; it MUST either
+	// restore them from archival storage, or load the instance from a snapshot
+	// (see [SnapshotBehavior]) taken after the most recent archived event.
+	ArchiveEventRetentionPolicy struct {
+		// After is the minimum age of an event before it may be archived. It
+		// MUST be positive.
+		After time.Duration
+	}
+
+	// DestroyWithInstanceEventRetentionPolicy is an [EventRetentionPolicy] that
+	// allows the engine to delete events when the aggregate instance that
+	// recorded them is destroyed, as per AggregateCommandScope.Destroy(). It's
+	// equivalent to [IndefiniteEventRetentionPolicy] for events recorded by
+	// handlers other than aggregates.
+	DestroyWithInstanceEventRetentionPolicy struct{}
+)
+
+// RetryPolicy describes how the engine retries failed attempts to handle
+// messages of a specific type.
+//
+// The zero-value of each field indicates that the engine's default behavior is
+// used for that aspect of the policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts to handle each message,
+	// including the first attempt.
+	//
+	// Once the limit is reached, the engine MUST NOT attempt to handle the
+	// message again, and SHOULD retain it in an engine-defined location such
+	// that it can be inspected by an operator.
+	MaxAttempts int
+
+	// InitialBackoff is a hint as to the delay that the engine should apply
+	// before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is a hint as to the maximum delay that the engine should apply
+	// between retries.
+	MaxBackoff time.Duration
+}
+
+// WithRetryPolicy returns an option that describes how the engine retries
+// failed attempts to handle messages of a specific type.
+func WithRetryPolicy(p RetryPolicy) RetryPolicyOption {
+	if p.MaxAttempts < 0 || p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		panic("retry policy must not contain negative values")
+	}
+	return RetryPolicyOption{p}
+}
+
+// RetryPolicyOption is an option that describes how the engine retries failed
+// attempts to handle messages of a specific type. See [WithRetryPolicy].
+type RetryPolicyOption struct{ p RetryPolicy }
+
+func (o RetryPolicyOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.RetryPolicy = o.p
+}
+
+func (o RetryPolicyOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.RetryPolicy = o.p
+}
+
+// WithEphemeralDelivery returns an option that declares that events of a
+// specific type are delivered on a best-effort basis and need not be persisted.
+//
+// It's intended for high-volume events that lose their value quickly, such as
+// telemetry or presence notifications.
+//
+// When used with [RecordsEvent], the engine MAY skip durable persistence of
+// events of this type, in which case any [EventRetentionPolicy] is moot. When
+// used with [HandlesEvent], the handler accepts that the engine MAY fail to
+// deliver some events of this type, for example if they're recorded while the
+// handler is unavailable.
+//
+// The option is not valid on a [RecordsEvent] route that belongs to an
+// [AggregateMessageHandler], as the engine rebuilds an aggregate's state from
+// the events it has recorded. The engine MUST report a configuration error if
+// it's used on such a route.
+//
+// An [EventReader] MAY omit events that the engine didn't persist durably.
+//
+// The engine MUST NOT use this option to weaken the guarantees it provides for
+// any other message type.
+func WithEphemeralDelivery() EphemeralDeliveryOption {
+	return EphemeralDeliveryOption{}
+}
+
+// EphemeralDeliveryOption is an option that declares that events of a specific
+// type are delivered on a best-effort basis and need not be persisted. See
+// [WithEphemeralDelivery].
+type EphemeralDeliveryOption struct{}
+
+func (EphemeralDeliveryOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.IsEphemeral = true
+}
+
+func (EphemeralDeliveryOption) applyToRecordsEvent(r *RecordsEventRoute) {
+	r.IsEphemeral = true
+}
+
+// AtMostOnce returns an option that requests at-most-once delivery of messages
+// of a specific type to the handler.
+//
+// It's intended for messages where a duplicate is worse than a loss, such as a
+// command that sends an SMS notification.
+//
+// The engine MUST NOT begin more than one attempt to handle each message of
+// this type. If the attempt fails, or its outcome is unknown, such as when the
+// engine crashes while the handler is running, the engine MUST NOT retry and
+// SHOULD report the failure prominently. Any [RetryPolicy] is moot.
+//
+// Messages produced by the handler during a failed attempt are discarded as
+// they would be under normal delivery.
+func AtMostOnce() AtMostOnceOption {
+	return AtMostOnceOption{}
+}
+
+// AtMostOnceOption is an option that requests at-most-once delivery of messages
+// of a specific type. See [AtMostOnce].
+type AtMostOnceOption struct{}
+
+func (AtMostOnceOption) applyToHandlesCommand(r *HandlesCommandRoute) {
+	r.IsAtMostOnce = true
+}
+
+func (AtMostOnceOption) applyToHandlesEvent(r *HandlesEventRoute) {
+	r.IsAtMostOnce = true
+}
+
 // typeOf returns the [reflect.Type] for C, which must be a concrete
 // implementation of the interface I.
 func typeOf[I Message, C Message]() reflect.Type {
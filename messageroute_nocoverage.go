@@ -3,5 +3,10 @@ package dogma
 func (HandlesCommandRoute) isMessageRoute()   {}
 func (ExecutesCommandRoute) isMessageRoute()  {}
 func (HandlesEventRoute) isMessageRoute()     {}
+func (HandlesAnyEventRoute) isMessageRoute()  {}
 func (RecordsEventRoute) isMessageRoute()     {}
 func (SchedulesTimeoutRoute) isMessageRoute() {}
+
+func (IndefiniteEventRetentionPolicy) isEventRetentionPolicy()          {}
+func (ArchiveEventRetentionPolicy) isEventRetentionPolicy()             {}
+func (DestroyWithInstanceEventRetentionPolicy) isEventRetentionPolicy() {}
@@ -1,8 +1,11 @@
 package dogma_test
 
 import (
+	"maps"
 	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	. "github.com/dogmatiq/dogma"
 )
@@ -149,3 +152,578 @@ func TestSchedulesTimeout(t *testing.T) {
 		SchedulesTimeout[X]()
 	})
 }
+
+func TestWithPriorVersion(t *testing.T) {
+	type (
+		V1 = nonPointerReceivers[EventValidationScope]
+		V2 = *pointerReceivers[EventValidationScope]
+		V3 = noPayloadEvent
+		X  = *nonPointerReceivers[EventValidationScope]
+	)
+
+	t.Run("it adds the prior versions to the route in order", func(t *testing.T) {
+		r := HandlesEvent[V3](
+			WithPriorVersion[V1](),
+			WithPriorVersion[V2](),
+		)
+
+		if len(r.PriorVersions) != 2 {
+			t.Fatalf("unexpected number of prior versions: %d", len(r.PriorVersions))
+		}
+
+		if r.PriorVersions[0] != reflect.TypeFor[V1]() {
+			t.Fatal("unexpected message type")
+		}
+
+		if r.PriorVersions[1] != reflect.TypeFor[V2]() {
+			t.Fatal("unexpected message type")
+		}
+	})
+
+	t.Run("it panics if the type is a pointer to an implementation that uses non-pointer receivers", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithPriorVersion[X]()
+	})
+}
+
+func TestWithSamplingRate(t *testing.T) {
+	type (
+		C = nonPointerReceivers[CommandValidationScope]
+		E = nonPointerReceivers[EventValidationScope]
+	)
+
+	t.Run("it sets the sampling rate on the route", func(t *testing.T) {
+		opt := WithSamplingRate(0.01)
+
+		if HandlesCommand[C](opt).SamplingRate != 0.01 {
+			t.Fatal("unexpected sampling rate on command route")
+		}
+
+		if HandlesEvent[E](opt).SamplingRate != 0.01 {
+			t.Fatal("unexpected sampling rate on event route")
+		}
+	})
+
+	t.Run("it panics if the sampling rate is out of range", func(t *testing.T) {
+		for _, p := range []float64{-1, 0, 1.01} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Fatalf("expected a panic for %v", p)
+					}
+				}()
+				WithSamplingRate(p)
+			}()
+		}
+	})
+}
+
+func TestHandlesEventWhere(t *testing.T) {
+	type (
+		N = nonPointerReceivers[EventValidationScope]
+		X = *nonPointerReceivers[EventValidationScope]
+	)
+
+	t.Run("it returns a route with the correct reflection type", func(t *testing.T) {
+		r := HandlesEventWhere(func(N) bool { return true })
+
+		if r.Type != reflect.TypeFor[N]() {
+			t.Fatal("unexpected message type")
+		}
+	})
+
+	t.Run("it returns a route that uses the predicate", func(t *testing.T) {
+		r := HandlesEventWhere(func(N) bool { return false })
+
+		if r.Predicate(N{}) {
+			t.Fatal("expected predicate to return false")
+		}
+	})
+
+	t.Run("it returns a route that accepts prior versions of the event", func(t *testing.T) {
+		r := HandlesEventWhere(
+			func(noPayloadEvent) bool { return false },
+			WithPriorVersion[N](),
+		)
+
+		if !r.Predicate(N{}) {
+			t.Fatal("expected predicate to return true for prior version")
+		}
+
+		if r.Predicate(noPayloadEvent{}) {
+			t.Fatal("expected predicate to return false for current version")
+		}
+	})
+
+	t.Run("it panics if the predicate is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		HandlesEventWhere[N](nil)
+	})
+
+	t.Run("it panics if the type is a pointer to an implementation that uses non-pointer receivers", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		HandlesEventWhere(func(X) bool { return true })
+	})
+}
+
+func TestWithRoutePriority(t *testing.T) {
+	type (
+		C = nonPointerReceivers[CommandValidationScope]
+		E = nonPointerReceivers[EventValidationScope]
+	)
+
+	opt := WithRoutePriority(10)
+
+	if HandlesCommand[C](opt).Priority != 10 {
+		t.Fatal("unexpected priority on command route")
+	}
+
+	if HandlesEvent[E](opt).Priority != 10 {
+		t.Fatal("unexpected priority on event route")
+	}
+}
+
+func TestWithMinimumDelay(t *testing.T) {
+	type C = nonPointerReceivers[CommandValidationScope]
+
+	t.Run("it sets the delay on the route", func(t *testing.T) {
+		r := ExecutesCommand[C](WithMinimumDelay(time.Minute))
+
+		if r.MinimumDelay != time.Minute {
+			t.Fatal("unexpected delay")
+		}
+	})
+
+	t.Run("it panics if the delay is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithMinimumDelay(0)
+	})
+}
+
+func TestWithMaxConcurrency(t *testing.T) {
+	type (
+		C = nonPointerReceivers[CommandValidationScope]
+		E = nonPointerReceivers[EventValidationScope]
+	)
+
+	t.Run("it sets the concurrency limit on the route", func(t *testing.T) {
+		opt := WithMaxConcurrency(5)
+
+		if HandlesCommand[C](opt).MaxConcurrency != 5 {
+			t.Fatal("unexpected concurrency limit on command route")
+		}
+
+		if HandlesEvent[E](opt).MaxConcurrency != 5 {
+			t.Fatal("unexpected concurrency limit on event route")
+		}
+	})
+
+	t.Run("it panics if the concurrency limit is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithMaxConcurrency(0)
+	})
+}
+
+func TestWithDeprecated(t *testing.T) {
+	type (
+		C  = nonPointerReceivers[CommandValidationScope]
+		E  = nonPointerReceivers[EventValidationScope]
+		TO = nonPointerReceivers[TimeoutValidationScope]
+	)
+
+	t.Run("it sets the deprecation reason on the route", func(t *testing.T) {
+		opt := WithDeprecated("<reason>")
+
+		if HandlesCommand[C](opt).DeprecationReason != "<reason>" {
+			t.Fatal("unexpected deprecation reason on handles-command route")
+		}
+
+		if ExecutesCommand[C](opt).DeprecationReason != "<reason>" {
+			t.Fatal("unexpected deprecation reason on executes-command route")
+		}
+
+		if HandlesEvent[E](opt).DeprecationReason != "<reason>" {
+			t.Fatal("unexpected deprecation reason on handles-event route")
+		}
+
+		if HandlesAnyEvent(opt).DeprecationReason != "<reason>" {
+			t.Fatal("unexpected deprecation reason on handles-any-event route")
+		}
+
+		if RecordsEvent[E](opt).DeprecationReason != "<reason>" {
+			t.Fatal("unexpected deprecation reason on records-event route")
+		}
+
+		if SchedulesTimeout[TO](opt).DeprecationReason != "<reason>" {
+			t.Fatal("unexpected deprecation reason on schedules-timeout route")
+		}
+	})
+
+	t.Run("it panics if the reason is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithDeprecated("")
+	})
+}
+
+func TestExposeExternally(t *testing.T) {
+	type C = nonPointerReceivers[CommandValidationScope]
+
+	t.Run("it marks the route as exposed externally", func(t *testing.T) {
+		r := HandlesCommand[C]()
+
+		if r.IsExposedExternally {
+			t.Fatal("did not expect route to be exposed externally by default")
+		}
+
+		r = HandlesCommand[C](ExposeExternally())
+
+		if !r.IsExposedExternally {
+			t.Fatal("expected route to be exposed externally")
+		}
+	})
+
+	t.Run("it sets the authorization scopes on the route", func(t *testing.T) {
+		r := HandlesCommand[C](ExposeExternally("<scope-a>", "<scope-b>"))
+
+		if !slices.Equal(r.AuthScopes, []string{"<scope-a>", "<scope-b>"}) {
+			t.Fatalf("unexpected authorization scopes: %v", r.AuthScopes)
+		}
+	})
+
+	t.Run("it panics if a scope is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		ExposeExternally("")
+	})
+}
+
+func TestWithRouteLabel(t *testing.T) {
+	type (
+		C  = nonPointerReceivers[CommandValidationScope]
+		E  = nonPointerReceivers[EventValidationScope]
+		TO = nonPointerReceivers[TimeoutValidationScope]
+	)
+
+	t.Run("it sets the labels on the route", func(t *testing.T) {
+		opts := []RouteLabelOption{
+			WithRouteLabel("team", "<team>"),
+			WithRouteLabel("tier", "<tier-1>"),
+			WithRouteLabel("tier", "<tier-2>"),
+		}
+
+		want := map[string]string{
+			"team": "<team>",
+			"tier": "<tier-2>",
+		}
+
+		check := func(t *testing.T, got map[string]string) {
+			t.Helper()
+			if !maps.Equal(got, want) {
+				t.Fatalf("unexpected labels: %v", got)
+			}
+		}
+
+		check(t, HandlesCommand[C](opts[0], opts[1], opts[2]).Labels)
+		check(t, ExecutesCommand[C](opts[0], opts[1], opts[2]).Labels)
+		check(t, HandlesEvent[E](opts[0], opts[1], opts[2]).Labels)
+		check(t, HandlesAnyEvent(opts[0], opts[1], opts[2]).Labels)
+		check(t, RecordsEvent[E](opts[0], opts[1], opts[2]).Labels)
+		check(t, SchedulesTimeout[TO](opts[0], opts[1], opts[2]).Labels)
+	})
+
+	t.Run("it panics if the key is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRouteLabel("", "<value>")
+	})
+}
+
+func TestStartsInstance(t *testing.T) {
+	type E = nonPointerReceivers[EventValidationScope]
+
+	if HandlesEvent[E]().StartsInstance {
+		t.Fatal("did not expect route to start instances by default")
+	}
+
+	if !HandlesEvent[E](StartsInstance()).StartsInstance {
+		t.Fatal("expected route to start instances")
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	type C = nonPointerReceivers[CommandValidationScope]
+
+	if HandlesCommand[C]().IsIdempotent {
+		t.Fatal("did not expect route to be idempotent by default")
+	}
+
+	if !HandlesCommand[C](Idempotent()).IsIdempotent {
+		t.Fatal("expected route to be idempotent")
+	}
+}
+
+func TestWithMaxScheduleHorizon(t *testing.T) {
+	type TO = nonPointerReceivers[TimeoutValidationScope]
+
+	t.Run("it sets the horizon on the route", func(t *testing.T) {
+		r := SchedulesTimeout[TO](WithMaxScheduleHorizon(24 * time.Hour))
+
+		if r.MaxScheduleHorizon != 24*time.Hour {
+			t.Fatal("unexpected horizon")
+		}
+	})
+
+	t.Run("it panics if the horizon is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithMaxScheduleHorizon(0)
+	})
+}
+
+func TestWithHandlingDeadline(t *testing.T) {
+	type C = nonPointerReceivers[CommandValidationScope]
+
+	t.Run("it sets the deadline on the route", func(t *testing.T) {
+		r := HandlesCommand[C](WithHandlingDeadline(10 * time.Second))
+
+		if r.HandlingDeadline != 10*time.Second {
+			t.Fatal("unexpected deadline")
+		}
+	})
+
+	t.Run("it panics if the deadline is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithHandlingDeadline(0)
+	})
+}
+
+type routesBuilder struct {
+	routes []MessageRoute
+}
+
+func (b *routesBuilder) HandlesCommand(r HandlesCommandRoute)     { b.routes = append(b.routes, r) }
+func (b *routesBuilder) ExecutesCommand(r ExecutesCommandRoute)   { b.routes = append(b.routes, r) }
+func (b *routesBuilder) HandlesEvent(r HandlesEventRoute)         { b.routes = append(b.routes, r) }
+func (b *routesBuilder) HandlesAnyEvent(r HandlesAnyEventRoute)   { b.routes = append(b.routes, r) }
+func (b *routesBuilder) RecordsEvent(r RecordsEventRoute)         { b.routes = append(b.routes, r) }
+func (b *routesBuilder) SchedulesTimeout(r SchedulesTimeoutRoute) { b.routes = append(b.routes, r) }
+
+func TestMessageRoute_ApplyMessageRoute(t *testing.T) {
+	type (
+		C  = nonPointerReceivers[CommandValidationScope]
+		E  = nonPointerReceivers[EventValidationScope]
+		TO = nonPointerReceivers[TimeoutValidationScope]
+	)
+
+	routes := []MessageRoute{
+		HandlesCommand[C](),
+		ExecutesCommand[C](),
+		HandlesEvent[E](),
+		HandlesAnyEvent(),
+		RecordsEvent[E](),
+		SchedulesTimeout[TO](),
+	}
+
+	b := &routesBuilder{}
+	for _, r := range routes {
+		r.ApplyMessageRoute(b)
+	}
+
+	if len(b.routes) != len(routes) {
+		t.Fatalf("unexpected number of routes: %d", len(b.routes))
+	}
+
+	for i, r := range routes {
+		if reflect.TypeOf(b.routes[i]) != reflect.TypeOf(r) {
+			t.Fatalf("unexpected route at index %d: %T", i, b.routes[i])
+		}
+	}
+}
+
+func TestHandlesEventFrom(t *testing.T) {
+	type (
+		N = nonPointerReceivers[EventValidationScope]
+		X = *nonPointerReceivers[EventValidationScope]
+	)
+
+	const key = "8c3bcb3c-8a1c-4bd7-9f22-6b7a7e0e7a53"
+
+	t.Run("it returns a route with the correct reflection type", func(t *testing.T) {
+		if HandlesEventFrom[N](key).Type != reflect.TypeFor[N]() {
+			t.Fatal("unexpected message type")
+		}
+	})
+
+	t.Run("it sets the source application key on the route", func(t *testing.T) {
+		if HandlesEvent[N]().SourceApplicationKey != "" {
+			t.Fatal("did not expect a source application key by default")
+		}
+
+		if HandlesEventFrom[N](key).SourceApplicationKey != key {
+			t.Fatal("unexpected source application key")
+		}
+	})
+
+	t.Run("it panics if the key is empty", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		HandlesEventFrom[N]("")
+	})
+
+	t.Run("it panics if the key is not a UUID", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		HandlesEventFrom[N]("<not-a-uuid>")
+	})
+
+	t.Run("it panics if the type is a pointer to an implementation that uses non-pointer receivers", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		HandlesEventFrom[X](key)
+	})
+}
+
+func TestWithRetention(t *testing.T) {
+	type E = nonPointerReceivers[EventValidationScope]
+
+	t.Run("it sets the retention policy on the route", func(t *testing.T) {
+		p := ArchiveEventRetentionPolicy{After: 365 * 24 * time.Hour}
+		r := RecordsEvent[E](WithRetention(p))
+
+		if r.RetentionPolicy != p {
+			t.Fatal("unexpected retention policy")
+		}
+	})
+
+	t.Run("it panics if the policy is nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRetention(nil)
+	})
+
+	t.Run("it panics if the archive period is not positive", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRetention(ArchiveEventRetentionPolicy{})
+	})
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	type (
+		C = nonPointerReceivers[CommandValidationScope]
+		E = nonPointerReceivers[EventValidationScope]
+	)
+
+	t.Run("it sets the retry policy on the route", func(t *testing.T) {
+		p := RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+			MaxBackoff:     time.Minute,
+		}
+		opt := WithRetryPolicy(p)
+
+		if HandlesCommand[C](opt).RetryPolicy != p {
+			t.Fatal("unexpected retry policy on command route")
+		}
+
+		if HandlesEvent[E](opt).RetryPolicy != p {
+			t.Fatal("unexpected retry policy on event route")
+		}
+	})
+
+	t.Run("it panics if the policy contains negative values", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		WithRetryPolicy(RetryPolicy{MaxAttempts: -1})
+	})
+}
+
+func TestWithEphemeralDelivery(t *testing.T) {
+	type E = nonPointerReceivers[EventValidationScope]
+
+	if HandlesEvent[E]().IsEphemeral || RecordsEvent[E]().IsEphemeral {
+		t.Fatal("did not expect route to be ephemeral by default")
+	}
+
+	if !HandlesEvent[E](WithEphemeralDelivery()).IsEphemeral {
+		t.Fatal("expected handles-event route to be ephemeral")
+	}
+
+	if !RecordsEvent[E](WithEphemeralDelivery()).IsEphemeral {
+		t.Fatal("expected records-event route to be ephemeral")
+	}
+}
+
+func TestAtMostOnce(t *testing.T) {
+	type (
+		C = nonPointerReceivers[CommandValidationScope]
+		E = nonPointerReceivers[EventValidationScope]
+	)
+
+	if HandlesCommand[C]().IsAtMostOnce || HandlesEvent[E]().IsAtMostOnce {
+		t.Fatal("did not expect route to be at-most-once by default")
+	}
+
+	if !HandlesCommand[C](AtMostOnce()).IsAtMostOnce {
+		t.Fatal("expected command route to be at-most-once")
+	}
+
+	if !HandlesEvent[E](AtMostOnce()).IsAtMostOnce {
+		t.Fatal("expected event route to be at-most-once")
+	}
+}
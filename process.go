@@ -2,6 +2,7 @@ package dogma
 
 import (
 	"context"
+	"reflect"
 	"time"
 )
 
@@ -45,7 +46,11 @@ type ProcessMessageHandler interface {
 	// If ok is false, the process ignores this event. Otherwise, id MUST not be
 	// empty. RFC 4122 UUIDs are the RECOMMENDED format for instance IDs.
 	//
-	// A process instance begins the first time it receives an event.
+	// A process instance begins the first time it receives an event, unless
+	// any of the handler's [HandlesEventRoute] routes use the [StartsInstance]
+	// option, in which case it begins the first time it receives an event on
+	// such a route. Events on other routes are ignored if the instance has not
+	// begun or has already ended.
 	RouteEventToInstance(context.Context, Event) (id string, ok bool, err error)
 
 	// HandleEvent begins or continues the process in response to an event.
@@ -106,6 +111,15 @@ type ProcessConfigurer interface {
 	// SchedulesTimeout() route types.
 	Routes(...ProcessRoute)
 
+	// UnroutedTimeoutPolicy configures how the engine handles timeouts that
+	// were scheduled by the handler, but are no longer routed to it.
+	//
+	// This can occur when a SchedulesTimeout() route is removed while timeouts
+	// of that type are still pending.
+	//
+	// The default policy is DeadLetterUnroutedTimeoutPolicy.
+	UnroutedTimeoutPolicy(UnroutedTimeoutPolicy)
+
 	// Disable prevents the handler from receiving any messages.
 	//
 	// The engine MUST NOT call any methods other than Configure() on a disabled
@@ -116,11 +130,20 @@ type ProcessConfigurer interface {
 	// without requiring the user to conditionally register the handler with the
 	// application.
 	Disable(...DisableOption)
+
+	// Warn records a non-fatal warning about the handler's configuration.
+	//
+	// The engine SHOULD report warnings prominently when it starts, such that
+	// the developer is able to address them. For example, an application may
+	// use warnings to announce that a route is deprecated.
+	Warn(format string, args ...any)
 }
 
 // ProcessEventScope performs engine operations within the context of a call
 // to the HandleEvent() method of a [ProcessMessageHandler].
 type ProcessEventScope interface {
+	HandlerScope
+
 	// InstanceID returns the ID of the process instance.
 	InstanceID() string
 
@@ -140,7 +163,7 @@ type ProcessEventScope interface {
 	// ExecuteCommand executes a command as a result of the event.
 	//
 	// Executing a command cancels any prior call to End() on this scope.
-	ExecuteCommand(Command)
+	ExecuteCommand(Command, ...ExecuteCommandOption)
 
 	// ScheduleTimeout schedules a timeout to occur at a specific time.
 	//
@@ -150,14 +173,13 @@ type ProcessEventScope interface {
 
 	// RecordedAt returns the time at which the event occurred.
 	RecordedAt() time.Time
-
-	// Log records an informational message.
-	Log(format string, args ...any)
 }
 
 // ProcessTimeoutScope performs engine operations within the context of a call
 // to the HandleTimeout() method of a [ProcessMessageHandler].
 type ProcessTimeoutScope interface {
+	HandlerScope
+
 	// InstanceID returns the ID of the process instance.
 	InstanceID() string
 
@@ -177,7 +199,7 @@ type ProcessTimeoutScope interface {
 	// ExecuteCommand executes a command as a result of the timeout.
 	//
 	// Executing a command cancels any prior call to End() on this scope.
-	ExecuteCommand(Command)
+	ExecuteCommand(Command, ...ExecuteCommandOption)
 
 	// ScheduleTimeout schedules a timeout to occur at a specific time.
 	//
@@ -191,8 +213,15 @@ type ProcessTimeoutScope interface {
 	// deliver timeouts that were "missed" after recovering from downtime.
 	ScheduledFor() time.Time
 
-	// Log records an informational message.
-	Log(format string, args ...any)
+	// ScheduledBy identifies the message that was being handled when the
+	// timeout was scheduled; that is, the [Event] or [Timeout] that was passed
+	// to the HandleEvent() or HandleTimeout() call that scheduled this timeout.
+	//
+	// t is the message's type. id is the engine-defined ID of the message, or
+	// an empty string if the engine does not assign message IDs. The engine
+	// only needs to store these values alongside each timeout, not a copy of
+	// the message itself.
+	ScheduledBy() (id string, t reflect.Type)
 }
 
 // StatelessProcessRoot is an implementation of [ProcessRoot] for processes that
@@ -230,6 +259,26 @@ func (NoTimeoutMessagesBehavior) HandleTimeout(
 	panic(UnexpectedMessage)
 }
 
+type (
+	// An UnroutedTimeoutPolicy describes how the engine handles a [Timeout]
+	// that's due for delivery to a [ProcessMessageHandler] that no longer has a
+	// [SchedulesTimeoutRoute] for that timeout's type.
+	//
+	// Under all policies, the engine MUST NOT call HandleTimeout() with such a
+	// timeout.
+	UnroutedTimeoutPolicy interface{ isUnroutedTimeoutPolicy() }
+
+	// DeadLetterUnroutedTimeoutPolicy is the default [UnroutedTimeoutPolicy].
+	// It retains unrouted timeouts in an engine-defined location such that
+	// they can be inspected, and potentially redelivered, by an operator.
+	DeadLetterUnroutedTimeoutPolicy struct{}
+
+	// DiscardUnroutedTimeoutPolicy is an [UnroutedTimeoutPolicy] that
+	// discards unrouted timeouts. The engine SHOULD log each discarded
+	// timeout.
+	DiscardUnroutedTimeoutPolicy struct{}
+)
+
 // ProcessRoute describes a message type that's routed to or from a
 // [ProcessMessageHandler].
 type ProcessRoute interface {
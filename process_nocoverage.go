@@ -3,3 +3,6 @@ package dogma
 func (HandlesEventRoute) isProcessRoute()     {}
 func (ExecutesCommandRoute) isProcessRoute()  {}
 func (SchedulesTimeoutRoute) isProcessRoute() {}
+
+func (DeadLetterUnroutedTimeoutPolicy) isUnroutedTimeoutPolicy() {}
+func (DiscardUnroutedTimeoutPolicy) isUnroutedTimeoutPolicy()    {}
@@ -96,7 +96,8 @@ type ProjectionConfigurer interface {
 	// Routes configures the engine to route certain message types to and from
 	// the handler.
 	//
-	// Projection handlers support the HandlesEvent() route type.
+	// Projection handlers support the HandlesEvent() and HandlesAnyEvent()
+	// route types.
 	Routes(...ProjectionRoute)
 
 	// DeliveryPolicy configures how the engine delivers events to the handler.
@@ -114,11 +115,20 @@ type ProjectionConfigurer interface {
 	// without requiring the user to conditionally register the handler with the
 	// application.
 	Disable(...DisableOption)
+
+	// Warn records a non-fatal warning about the handler's configuration.
+	//
+	// The engine SHOULD report warnings prominently when it starts, such that
+	// the developer is able to address them. For example, an application may
+	// use warnings to announce that a route is deprecated.
+	Warn(format string, args ...any)
 }
 
 // ProjectionEventScope performs engine operations within the context of a call
 // to the HandleEvent() method of a [ProjectionMessageHandler].
 type ProjectionEventScope interface {
+	HandlerScope
+
 	// RecordedAt returns the time at which the event occurred.
 	RecordedAt() time.Time
 
@@ -130,27 +140,46 @@ type ProjectionEventScope interface {
 	// by all applications, while still delivering the event to all instances of
 	// the application.
 	IsPrimaryDelivery() bool
-
-	// Log records an informational message.
-	Log(format string, args ...any)
 }
 
 // ProjectionCompactScope performs engine operations within the context of a
 // call to the Compact() method of a [ProjectionMessageHandler].
 type ProjectionCompactScope interface {
-	// Now returns the current engine time.
+	HandlerScope
+
+	// Clock provides the current engine time. The handler SHOULD use it to
+	// implement compaction logic that has some time-based component, such as
+	// removing data older than a certain age.
+	Clock
+
+	// RetentionPeriod returns the period for which the projection should
+	// retain time-based data, as configured within the engine.
+	//
+	// If ok is false, the engine has no retention period configured for this
+	// handler and the handler SHOULD use its own default policy.
 	//
-	// The handler SHOULD use the returned time to implement compaction logic
-	// that has some time-based component, such as removing data older than a
-	// certain age.
+	// This allows the same handler implementation to apply different retention
+	// policies in different deployments.
+	RetentionPeriod() (d time.Duration, ok bool)
+
+	// Cursor returns the cursor most recently passed to SetCursor() by any
+	// prior call to the handler's Compact() method.
 	//
-	// Under normal operating conditions the engine SHOULD return the current
-	// local time. The engine MAY return a different time under some
-	// circumstances, such as when executing tests.
-	Now() time.Time
+	// It returns nil if no cursor has been set, in which case the handler
+	// SHOULD begin compaction from the start of its data.
+	Cursor() []byte
 
-	// Log records an informational message.
-	Log(format string, args ...any)
+	// SetCursor sets a handler-defined cursor that records the handler's
+	// progress through the compaction of its data.
+	//
+	// It allows the handler to compact incrementally across multiple calls to
+	// Compact() without maintaining its own storage for that purpose. The
+	// handler SHOULD call this method only once the work preceding the cursor
+	// is durable. A nil cursor resets the handler's progress.
+	//
+	// The engine MUST persist the cursor once Compact() returns, regardless of
+	// whether it returns an error.
+	SetCursor(c []byte)
 }
 
 // NoCompactBehavior is an embeddable type for [ProjectionMessageHandler]
@@ -3,4 +3,5 @@ package dogma
 func (UnicastProjectionDeliveryPolicy) isProjectionDeliveryPolicy()   {}
 func (BroadcastProjectionDeliveryPolicy) isProjectionDeliveryPolicy() {}
 
-func (HandlesEventRoute) isProjectionRoute() {}
+func (HandlesEventRoute) isProjectionRoute()    {}
+func (HandlesAnyEventRoute) isProjectionRoute() {}
@@ -0,0 +1,131 @@
+package dogma
+
+import "time"
+
+// HandlerScope performs engine operations that are common to the scopes of all
+// message handler types.
+type HandlerScope interface {
+	Logger
+
+	// Annotate returns an error that wraps err with diagnostic information
+	// about the current scope.
+	//
+	// The diagnostic information SHOULD include the handler's identity, the ID
+	// of the message being handled and, where applicable, the instance ID. The
+	// engine MAY render this information in any machine-parseable format.
+	//
+	// The returned error MUST match err when compared using [errors.Is] and
+	// [errors.As]. If err is nil, it returns nil.
+	//
+	// The handler MAY use this method to annotate errors before returning
+	// them, such that errors logged by the engine carry consistent context.
+	Annotate(err error) error
+
+	// Attempt returns the number of times the engine has attempted to handle
+	// the current message, or to perform the current operation, including the
+	// current attempt.
+	//
+	// It returns 1 on the first attempt. The engine SHOULD count attempts
+	// across restarts and across all operating system processes, but MAY reset
+	// the count under some circumstances, such as if the count is stored in
+	// volatile memory.
+	Attempt() int
+
+	// Memo returns the result of calling fn, caching it under the given key
+	// for the duration of the handling of the current message.
+	//
+	// It allows handlers to avoid repeating expensive derivations, such as
+	// loading currency conversion tables, when the engine retries the current
+	// message. The key is scoped to the current message; the same key used
+	// while handling a different message refers to a different value.
+	//
+	// If fn returns an error, the engine MUST NOT cache the result. The engine
+	// MAY retain cached values across attempts, but is not required to do so;
+	// fn MAY be called on every attempt. Therefore, fn SHOULD NOT have side
+	// effects, and the cached value MUST NOT be modified by the handler.
+	Memo(key string, fn func() (any, error)) (any, error)
+
+	// NodeID returns an engine-defined identifier of the engine instance,
+	// node or operating system process that's handling the current message.
+	//
+	// The handler MAY include this value in logs or in events that describe
+	// operational concerns, such as failures in an external system, to aid in
+	// diagnosing problems that are specific to a particular node or region.
+	// It MUST NOT use this value to alter its business logic.
+	//
+	// It returns an empty string if the engine has no such identifier.
+	NodeID() string
+
+	// DeploymentVersion returns an engine-defined identifier of the deployed
+	// version of the application, such as a build number or version control
+	// revision.
+	//
+	// The same caveats apply as for NodeID(). It returns an empty string if the
+	// engine has no such identifier.
+	DeploymentVersion() string
+}
+
+// Logger is the subset of [HandlerScope] that records log messages.
+//
+// It allows code such as middleware to depend on the minimal capability that it
+// requires, rather than on a specific scope type.
+type Logger interface {
+	// Log records an informational message.
+	Log(format string, args ...any)
+
+	// Debug records a diagnostic message.
+	//
+	// Debug messages are intended to aid developers in understanding the
+	// handler's behavior. The engine SHOULD NOT emit or persist debug messages
+	// unless configured to do so, such as when troubleshooting.
+	Debug(format string, args ...any)
+
+	// Warn records a message that indicates a potential problem that does not
+	// prevent the handler from operating.
+	//
+	// The engine SHOULD emit or persist warning messages under all
+	// configurations that emit or persist informational messages.
+	Warn(format string, args ...any)
+}
+
+// Clock provides access to the engine's time.
+//
+// It's embedded in the scopes of those handlers that may legitimately depend on
+// the current time: [IntegrationCommandScope] and [ProjectionCompactScope].
+// It's deliberately absent from [AggregateCommandScope], as an aggregate's
+// behavior MUST depend only on its state and the command being handled, and
+// from the process scopes, which use "model time" as per ADR-11.
+//
+// It allows code such as middleware to depend on the minimal capability that it
+// requires, rather than on a specific scope type.
+type Clock interface {
+	// Now returns the current engine time.
+	//
+	// Under normal operating conditions the engine SHOULD return the current
+	// local time. The engine MAY return a different time under some
+	// circumstances, such as when executing tests.
+	Now() time.Time
+
+	// NowWithUncertainty returns the current engine time, along with a bound on
+	// the error of the engine's clock.
+	//
+	// The engine MUST return a bound such that the true current time is
+	// within the interval [t-u, t+u]. This allows handlers with time-sensitive
+	// logic, such as closing an auction, to reason about clock skew explicitly.
+	//
+	// The engine MUST NOT return a negative value for u. The engine SHOULD
+	// derive u from its clock synchronization mechanism. If it has no such
+	// information it MUST choose a value for u that's large enough to satisfy
+	// the requirement above.
+	NowWithUncertainty() (t time.Time, u time.Duration)
+}
+
+// InstanceScope is the subset of [AggregateCommandScope], [ProcessEventScope]
+// and [ProcessTimeoutScope] that identifies the handler instance.
+//
+// Code that accepts a [HandlerScope] can use a type assertion to determine
+// whether the scope is associated with a specific instance.
+type InstanceScope interface {
+	// InstanceID returns the ID of the aggregate or process instance.
+	InstanceID() string
+}